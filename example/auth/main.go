@@ -93,6 +93,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/sagikazarmark/go-withings/oauth2"
@@ -100,15 +101,12 @@ import (
 
 func main() {
 	// Initialize oauth2 config
-	config := &oauth2.WithingsConfig{
-		Config: &oauth2.Config{
-			ClientID:     os.Getenv("WITHINGS_CLIENT_ID"),
-			ClientSecret: os.Getenv("WITHINGS_CLIENT_SECRET"),
-			Scopes:       []string{"user.activity", "user.metrics", "user.sleepevents"},
-			Endpoint:     oauth2.Endpoint,
-			RedirectURL:  os.Getenv("WITHINGS_REDIRECT_URL"),
-		},
-	}
+	config := oauth2.NewConfig(
+		os.Getenv("WITHINGS_CLIENT_ID"),
+		os.Getenv("WITHINGS_CLIENT_SECRET"),
+		os.Getenv("WITHINGS_REDIRECT_URL"),
+		[]oauth2.Scope{oauth2.ScopeUserActivity, oauth2.ScopeUserMetrics, oauth2.ScopeUserSleepEvents},
+	)
 
 	// Initialize auth flow
 	url := config.AuthCodeURL("state", oauth2.ModeDemo)
@@ -136,8 +134,9 @@ func main() {
 		fmt.Printf("Expiry: %s\n", token.Expiry.Format(time.RFC3339))
 		fmt.Printf("Refresh token: %s\n", token.RefreshToken)
 		fmt.Printf("Token type: %s\n", token.TokenType)
-		fmt.Printf("User ID: %.0f\n", token.Extra("userid"))
-		fmt.Printf("Scope: %s\n", token.Extra("scope"))
+		userID, _ := oauth2.UserID(token)
+		fmt.Printf("User ID: %d\n", userID)
+		fmt.Printf("Scope: %s\n", strings.Join(oauth2.Scopes(token), ","))
 	})
 
 	err := http.ListenAndServe("127.0.0.1:8080", nil)
@@ -34,8 +34,17 @@ func main() {
 
 	opts := withings.MeasureGetOptions{
 		LastUpdate: now.Add(-24 * time.Hour),
-		StartDate:  now.Add(-24 * time.Hour),
-		EndDate:    now,
+	}
+
+	intradayOpts := withings.MeasureGetOptions{
+		StartDate: now.Add(-24 * time.Hour),
+		EndDate:   now,
+	}
+
+	activityOpts := withings.ActivityGetOptions{
+		DateRange: withings.DateRange{
+			LastUpdate: now.Add(-24 * time.Hour),
+		},
 	}
 
 	measures, _, err := client.Measure.Getmeas(
@@ -51,7 +60,7 @@ func main() {
 	activities, _, err := client.Measure.Getactivity(
 		context.Background(),
 		withings.AllActivityFields(),
-		opts,
+		activityOpts,
 	)
 	if err != nil {
 		log.Fatal(err)
@@ -60,7 +69,7 @@ func main() {
 	intradayactivities, _, err := client.Measure.Getintradayactivity(
 		context.Background(),
 		withings.AllIntradayActivityFields(),
-		opts,
+		intradayOpts,
 	)
 	if err != nil {
 		log.Fatal(err)
@@ -69,7 +78,7 @@ func main() {
 	workouts, _, err := client.Measure.Getworkouts(
 		context.Background(),
 		withings.AllWorkoutFields(),
-		opts,
+		activityOpts,
 	)
 	if err != nil {
 		log.Fatal(err)
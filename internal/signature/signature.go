@@ -0,0 +1,44 @@
+// Package signature implements signing requests sent to signature protected actions of the Withings API.
+//
+// Withings API docs: https://developer.withings.com/developer-guide/v3/get-access/sign-your-requests
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// signedFields is the list of form fields included in the signature (if present).
+var signedFields = []string{"action", "client_id", "nonce", "timestamp"}
+
+// Compute computes the signature of form.
+//
+// The signature is the hex encoded HMAC-SHA256 hash (keyed with the client secret)
+// of the comma separated values of the action, client_id, nonce and timestamp fields
+// sorted by field name.
+func Compute(clientSecret string, form url.Values) string {
+	keys := make([]string, 0, len(signedFields))
+
+	for _, key := range signedFields {
+		if _, ok := form[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	values := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		values = append(values, form.Get(key))
+	}
+
+	mac := hmac.New(sha256.New, []byte(clientSecret))
+	mac.Write([]byte(strings.Join(values, ",")))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
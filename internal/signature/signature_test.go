@@ -0,0 +1,22 @@
+package signature
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	form := url.Values{
+		"timestamp": {"1234567890"},
+		"action":    {"getnonce"},
+		"client_id": {"client"},
+		"other":     {"ignored"},
+	}
+
+	// echo -n "getnonce,client,1234567890" | openssl dgst -sha256 -hmac secret
+	const want = "df3dc4f62d7c98581c74a5b847e63cb73dfb5baf3dd0c57c0ca6162bf0823c9e"
+
+	if got := Compute("secret", form); got != want {
+		t.Errorf("Compute() = %q; want %q", got, want)
+	}
+}
@@ -16,23 +16,32 @@ import (
 
 // Client returns an HTTP client using the provided token.
 // The token will auto-refresh as necessary. The underlying
-// HTTP transport will be obtained using the provided context.
+// HTTP transport will be obtained using the provided context
+// (or HTTPClient if the context does not carry one).
 // The returned client and its Transport should not be modified.
 func (c *WithingsConfig) Client(ctx context.Context, t *oauth2.Token) *http.Client {
+	ctx = c.context(ctx)
+
 	return oauth2.NewClient(ctx, c.TokenSource(ctx, t))
 }
 
 // TokenSource returns a TokenSource that returns t until t expires,
 // automatically refreshing it as necessary using the provided context.
 //
+// OnNewToken (if set) is called with every refreshed token.
+//
 // Most users will use Config.Client instead.
 func (c *WithingsConfig) TokenSource(ctx context.Context, t *oauth2.Token) oauth2.TokenSource {
 	tkr := &tokenRefresher{
-		ctx:  ctx,
-		conf: c.Config,
+		ctx:        c.context(ctx),
+		conf:       c.Config,
+		onNewToken: c.OnNewToken,
+		maxRetries: c.RefreshMaxRetries,
+		retryWait:  c.RefreshRetryWait,
 	}
 	if t != nil {
 		tkr.refreshToken = t.RefreshToken
+		tkr.extra = carriedExtras(t)
 	}
 	return oauth2.ReuseTokenSource(t, tkr)
 }
@@ -41,22 +50,45 @@ func (c *WithingsConfig) TokenSource(ctx context.Context, t *oauth2.Token) oauth
 // This token is then mapped from *internal.Token into an *oauth2.Token which is returned along
 // with an error..
 func retrieveToken(ctx context.Context, c *Config, v url.Values) (*oauth2.Token, error) {
-	tk, err := internal.RetrieveToken(ctx, c.ClientID, c.ClientSecret, c.Endpoint.TokenURL, v, internal.AuthStyle(c.Endpoint.AuthStyle))
+	return retrieveTokenWithExtras(ctx, c, v, nil)
+}
+
+// retrieveTokenWithExtras works like retrieveToken,
+// but falls back to the provided extras when they are missing from the token response.
+func retrieveTokenWithExtras(ctx context.Context, c *Config, v url.Values, extra map[string]interface{}) (*oauth2.Token, error) {
+	// Withings expects client credentials in the request body:
+	// don't try to autodetect the auth style (eg. when the endpoint is configured manually).
+	authStyle := internal.AuthStyle(c.Endpoint.AuthStyle)
+	if authStyle == internal.AuthStyleUnknown {
+		authStyle = internal.AuthStyleInParams
+	}
+
+	tk, err := internal.RetrieveToken(ctx, c.ClientID, c.ClientSecret, c.Endpoint.TokenURL, v, authStyle)
 	if err != nil {
 		if rErr, ok := err.(*internal.RetrieveError); ok { // nolint: errorlint
 			return nil, (*oauth2.RetrieveError)(rErr)
 		}
 		return nil, err
 	}
-	return tokenFromInternal(tk), nil
+	return tokenFromInternal(tk, extra), nil
 }
 
 // tokenFromInternal maps an *internal.Token struct into
 // a *Token struct.
-func tokenFromInternal(t *internal.Token) *oauth2.Token {
+//
+// Keys of extra missing from the raw token response are added to the token extras.
+func tokenFromInternal(t *internal.Token, extra map[string]interface{}) *oauth2.Token {
 	if t == nil {
 		return nil
 	}
+
+	if raw, ok := t.Raw.(map[string]interface{}); ok {
+		for key, value := range extra {
+			if _, ok := raw[key]; !ok {
+				raw[key] = value
+			}
+		}
+	}
 	return (&oauth2.Token{
 		AccessToken:  t.AccessToken,
 		TokenType:    t.TokenType,
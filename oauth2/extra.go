@@ -0,0 +1,66 @@
+package oauth2
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// UserID returns the Withings user ID the token was issued for.
+//
+// The user ID is returned as an extra field in the token response.
+// The second return value is false if the token does not contain a valid user ID.
+func UserID(token *oauth2.Token) (int64, bool) {
+	if token == nil {
+		return 0, false
+	}
+
+	switch v := token.Extra("userid").(type) {
+	case float64:
+		return int64(v), true
+
+	case int64:
+		return v, true
+
+	case int:
+		return int64(v), true
+
+	case json.Number:
+		id, err := v.Int64()
+
+		return id, err == nil
+
+	case string:
+		id, err := strconv.ParseInt(v, 10, 64)
+
+		return id, err == nil
+	}
+
+	return 0, false
+}
+
+// Scopes returns the list of scopes granted by the user.
+//
+// The scopes are returned as a comma separated extra field in the token response.
+func Scopes(token *oauth2.Token) []string {
+	if token == nil {
+		return nil
+	}
+
+	scope, ok := token.Extra("scope").(string)
+	if !ok || scope == "" {
+		return nil
+	}
+
+	var scopes []string
+
+	for _, s := range strings.Split(scope, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+
+	return scopes
+}
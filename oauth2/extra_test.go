@@ -0,0 +1,52 @@
+package oauth2
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestUserID(t *testing.T) {
+	tests := []struct {
+		name   string
+		userID interface{}
+		want   int64
+		ok     bool
+	}{
+		{"Float", float64(363), 363, true},
+		{"String", "363", 363, true},
+		{"InvalidString", "abc", 0, false},
+		{"Missing", nil, 0, false},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			extra := map[string]interface{}{}
+			if test.userID != nil {
+				extra["userid"] = test.userID
+			}
+
+			token := (&oauth2.Token{AccessToken: "token"}).WithExtra(extra)
+
+			got, ok := UserID(token)
+			if got != test.want || ok != test.ok {
+				t.Errorf("UserID() = (%d, %t); want (%d, %t)", got, ok, test.want, test.ok)
+			}
+		})
+	}
+}
+
+func TestScopes(t *testing.T) {
+	token := (&oauth2.Token{AccessToken: "token"}).WithExtra(map[string]interface{}{
+		"scope": "user.activity,user.metrics, user.sleepevents",
+	})
+
+	want := []string{"user.activity", "user.metrics", "user.sleepevents"}
+
+	if got := Scopes(token); !reflect.DeepEqual(got, want) {
+		t.Errorf("Scopes() = %v; want %v", got, want)
+	}
+}
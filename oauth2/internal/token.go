@@ -269,6 +269,14 @@ func doTokenRoundTrip(ctx context.Context, req *http.Request) (*Token, error) {
 		if err = json.Unmarshal(body, &tj); err != nil {
 			return nil, err
 		}
+		// Withings responds with 200 OK even if the request fails:
+		// the actual status is in the response envelope.
+		if tj.Status != 0 {
+			return nil, &RetrieveError{
+				Response: r,
+				Body:     body,
+			}
+		}
 		token = &Token{
 			AccessToken:  tj.Body.AccessToken,
 			TokenType:    tj.Body.TokenType,
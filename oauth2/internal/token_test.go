@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 )
 
@@ -105,3 +106,22 @@ func TestRetrieveToken_Extras(t *testing.T) {
 		t.Errorf("unexpected userid: %v", userid)
 	}
 }
+
+func TestRetrieveToken_StatusError(t *testing.T) {
+	ResetAuthCache()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"status": 503, "error": "Invalid Params: invalid client_id"}`)
+	}))
+	defer ts.Close()
+	_, err := RetrieveToken(context.Background(), "client-id", "", ts.URL, url.Values{}, AuthStyleInParams)
+	if err == nil {
+		t.Fatal("RetrieveToken = nil; want error")
+	}
+	if _, ok := err.(*RetrieveError); !ok {
+		t.Errorf("RetrieveToken error = %T; want *RetrieveError", err)
+	}
+	if !strings.Contains(err.Error(), "invalid client_id") {
+		t.Errorf("RetrieveToken error = %q; want it to contain the Withings error", err)
+	}
+}
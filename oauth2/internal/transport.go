@@ -7,6 +7,8 @@ package internal
 import (
 	"context"
 	"net/http"
+
+	"golang.org/x/oauth2"
 )
 
 // HTTPClient is the context key to use with golang.org/x/net/context's
@@ -25,6 +27,12 @@ func ContextClient(ctx context.Context) *http.Client {
 		if hc, ok := ctx.Value(HTTPClient).(*http.Client); ok {
 			return hc
 		}
+
+		// Honor the context key of golang.org/x/oauth2 as well,
+		// so the same context can be used with both packages.
+		if hc, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+			return hc
+		}
 	}
 	if appengineClientHook != nil {
 		return appengineClientHook(ctx)
@@ -16,8 +16,10 @@ package oauth2
 
 import (
 	"context"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"golang.org/x/oauth2"
 )
@@ -52,6 +54,122 @@ type Config = oauth2.Config
 // functionality with Withings specific behavior.
 type WithingsConfig struct {
 	*oauth2.Config
+
+	// HTTPClient is used for requests sent to the token endpoint
+	// (eg. exchanging authorization codes and refreshing tokens)
+	// and as the underlying transport of clients returned by Client,
+	// unless the context passed to a method already carries one
+	// under the oauth2.HTTPClient key.
+	//
+	// It can be used to inject a client with a retrying transport,
+	// so rate limited token refreshes don't fail immediately.
+	// If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// Demo flags the whole authorization flow as a demo flow:
+	// AuthCodeURL always adds the ModeDemo parameter, so the user is logged in as the demo user.
+	//
+	// Only the authorization endpoint honors demo mode.
+	// Tokens issued during a demo flow belong to the demo user,
+	// so token refreshes and API calls made with them
+	// (and tokens obtained by refreshing them) target the demo user as well.
+	// This makes it possible to run integration tests without a real account.
+	//
+	// Use withings.WithDemo to mark API clients using these tokens as demo clients.
+	Demo bool
+
+	// OnNewToken is called with every token obtained by refreshing an expired token
+	// in token sources (and clients) returned by TokenSource (and Client).
+	//
+	// Withings rotates refresh tokens, so long-lived services should use it
+	// to persist new tokens: otherwise a stale refresh token is used after a restart.
+	// Calls are synchronized by the token source, so OnNewToken is not called concurrently
+	// for the same token source.
+	OnNewToken func(t *oauth2.Token)
+
+	// RefreshMaxRetries is the maximum number of times a rate limited token refresh is retried
+	// by token sources (and clients) returned by TokenSource (and Client).
+	// Retries are disabled when RefreshMaxRetries is zero.
+	//
+	// Retries wait for a random duration (full jitter) bounded by an exponential backoff
+	// starting at RefreshRetryWait (one second if zero),
+	// so that refreshes of many token sources expiring at the same time (eg. in a batch sync)
+	// spread out instead of tripping the rate limit again.
+	RefreshMaxRetries int
+	RefreshRetryWait  time.Duration
+}
+
+// ConfigOption configures a WithingsConfig created by NewConfig.
+type ConfigOption func(c *WithingsConfig)
+
+// WithHIPAA configures the HIPAA endpoint (for both authorization and token requests).
+func WithHIPAA() ConfigOption {
+	return func(c *WithingsConfig) {
+		c.Endpoint = EndpointHIPAA
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests sent to the token endpoint.
+func WithHTTPClient(httpClient *http.Client) ConfigOption {
+	return func(c *WithingsConfig) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithDemo flags the authorization flow as a demo flow (see WithingsConfig.Demo).
+func WithDemo() ConfigOption {
+	return func(c *WithingsConfig) {
+		c.Demo = true
+	}
+}
+
+// WithOnNewToken sets a function called with every refreshed token (see WithingsConfig.OnNewToken).
+func WithOnNewToken(fn func(t *oauth2.Token)) ConfigOption {
+	return func(c *WithingsConfig) {
+		c.OnNewToken = fn
+	}
+}
+
+// WithRefreshRetries enables retrying rate limited token refreshes (see WithingsConfig.RefreshMaxRetries).
+func WithRefreshRetries(maxRetries int, wait time.Duration) ConfigOption {
+	return func(c *WithingsConfig) {
+		c.RefreshMaxRetries = maxRetries
+		c.RefreshRetryWait = wait
+	}
+}
+
+// NewConfig returns a new WithingsConfig for the Public endpoint.
+//
+// Use WithHIPAA to configure the HIPAA endpoint instead.
+func NewConfig(clientID string, clientSecret string, redirectURL string, scopes []Scope, opts ...ConfigOption) *WithingsConfig {
+	c := &WithingsConfig{
+		Config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Endpoint:     Endpoint,
+			RedirectURL:  redirectURL,
+			Scopes:       StringScopes(scopes...),
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// context returns ctx with the configured HTTP client (if any).
+func (c *WithingsConfig) context(ctx context.Context) context.Context {
+	if c.HTTPClient == nil {
+		return ctx
+	}
+
+	if _, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, oauth2.HTTPClient, c.HTTPClient)
 }
 
 // AuthCodeURL returns a URL to OAuth 2.0 provider's consent page
@@ -60,12 +178,18 @@ type WithingsConfig struct {
 // It wraps the same function from golang.org/x/oauth2.Config and
 // makes the scope parameter a comma separated string.
 //
+// If Demo is set, ModeDemo is added to the options automatically.
+//
 // https://developer.withings.com/api-reference#operation/oauth2-authorize
 func (c *WithingsConfig) AuthCodeURL(state string, opts ...oauth2.AuthCodeOption) string {
 	if len(c.Scopes) > 0 {
 		opts = append([]oauth2.AuthCodeOption{oauth2.SetAuthURLParam("scope", strings.Join(c.Scopes, ","))}, opts...)
 	}
 
+	if c.Demo {
+		opts = append(opts, ModeDemo)
+	}
+
 	return c.Config.AuthCodeURL(state, opts...)
 }
 
@@ -84,5 +208,5 @@ func (c *WithingsConfig) Exchange(ctx context.Context, code string) (*oauth2.Tok
 	if c.RedirectURL != "" {
 		v.Set("redirect_uri", c.RedirectURL)
 	}
-	return retrieveToken(ctx, c.Config, v)
+	return retrieveToken(c.context(ctx), c.Config, v)
 }
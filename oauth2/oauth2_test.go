@@ -0,0 +1,295 @@
+package oauth2
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWithingsConfig_HTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"status":0,"body":{"access_token":"ACCESS_TOKEN","refresh_token":"REFRESH_TOKEN","expires_in":10800,"userid":"363"}}`)
+	}))
+	defer server.Close()
+
+	transport := new(countingTransport)
+
+	config := &WithingsConfig{
+		Config: &oauth2.Config{
+			ClientID: "client-id",
+			Endpoint: oauth2.Endpoint{
+				TokenURL:  server.URL,
+				AuthStyle: oauth2.AuthStyleInParams,
+			},
+		},
+		HTTPClient: &http.Client{Transport: transport},
+	}
+
+	_, err := config.Exchange(context.Background(), "code")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := transport.requests, 1; got != want {
+		t.Errorf("requests = %d; want %d", got, want)
+	}
+
+	t.Run("ContextTakesPrecedence", func(t *testing.T) {
+		ctxTransport := new(countingTransport)
+
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: ctxTransport})
+
+		_, err := config.TokenSource(ctx, &oauth2.Token{RefreshToken: "REFRESH_TOKEN"}).Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := ctxTransport.requests, 1; got != want {
+			t.Errorf("requests = %d; want %d", got, want)
+		}
+	})
+}
+
+func TestNewConfig(t *testing.T) {
+	config := NewConfig("client-id", "client-secret", "https://example.com/callback", []Scope{ScopeUserInfo, ScopeUserMetrics})
+
+	if got, want := config.Endpoint, Endpoint; got != want {
+		t.Errorf("endpoint = %+v; want %+v", got, want)
+	}
+
+	if got, want := config.AuthCodeURL("state"), "scope=user.info%2Cuser.metrics"; !strings.Contains(got, want) {
+		t.Errorf("auth code URL = %q; want it to contain %q", got, want)
+	}
+
+	t.Run("HIPAA", func(t *testing.T) {
+		config := NewConfig("client-id", "client-secret", "https://example.com/callback", nil, WithHIPAA())
+
+		if got, want := config.Endpoint, EndpointHIPAA; got != want {
+			t.Errorf("endpoint = %+v; want %+v", got, want)
+		}
+	})
+
+	t.Run("Demo", func(t *testing.T) {
+		config := NewConfig("client-id", "client-secret", "https://example.com/callback", nil, WithDemo())
+
+		if got, want := config.AuthCodeURL("state"), "mode=demo"; !strings.Contains(got, want) {
+			t.Errorf("auth code URL = %q; want it to contain %q", got, want)
+		}
+	})
+}
+
+func TestWithingsConfig_TokenSource_CarriesExtras(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"status":0,"body":{"access_token":"NEW_ACCESS_TOKEN","refresh_token":"NEW_REFRESH_TOKEN","expires_in":10800}}`)
+	}))
+	defer server.Close()
+
+	config := &WithingsConfig{
+		Config: &oauth2.Config{
+			ClientID: "client-id",
+			Endpoint: oauth2.Endpoint{
+				TokenURL:  server.URL,
+				AuthStyle: oauth2.AuthStyleInParams,
+			},
+		},
+	}
+
+	token := (&oauth2.Token{RefreshToken: "REFRESH_TOKEN"}).WithExtra(map[string]interface{}{
+		"userid": "363",
+		"scope":  "user.info,user.metrics",
+	})
+
+	refreshed, err := config.TokenSource(context.Background(), token).Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := refreshed.AccessToken, "NEW_ACCESS_TOKEN"; got != want {
+		t.Errorf("access token = %q; want %q", got, want)
+	}
+
+	if userID, ok := UserID(refreshed); !ok || userID != 363 {
+		t.Errorf("user ID = %d; want 363", userID)
+	}
+
+	if got, want := Scopes(refreshed), []string{"user.info", "user.metrics"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("scopes = %v; want %v", got, want)
+	}
+}
+
+func TestWithingsConfig_OnNewToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"status":0,"body":{"access_token":"NEW_ACCESS_TOKEN","refresh_token":"NEW_REFRESH_TOKEN","expires_in":10800}}`)
+	}))
+	defer server.Close()
+
+	var tokens []*oauth2.Token
+
+	config := NewConfig("client-id", "client-secret", "", nil, WithOnNewToken(func(t *oauth2.Token) {
+		tokens = append(tokens, t)
+	}))
+	config.Endpoint.TokenURL = server.URL
+
+	ts := config.TokenSource(context.Background(), &oauth2.Token{RefreshToken: "REFRESH_TOKEN"})
+
+	for i := 0; i < 2; i++ {
+		_, err := ts.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(tokens) != 1 {
+		t.Fatalf("OnNewToken called %d times; want 1", len(tokens))
+	}
+
+	if got, want := tokens[0].RefreshToken, "NEW_REFRESH_TOKEN"; got != want {
+		t.Errorf("refresh token = %q; want %q", got, want)
+	}
+}
+
+func TestWithingsConfig_Exchange_Endpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := map[string]string{
+			"action":        "requesttoken",
+			"grant_type":    "authorization_code",
+			"code":          "code",
+			"client_id":     "client-id",
+			"client_secret": "client-secret",
+		}
+
+		for key, value := range want {
+			if got := r.PostFormValue(key); got != value {
+				t.Errorf("%s = %q; want %q", key, got, value)
+			}
+		}
+
+		if _, _, ok := r.BasicAuth(); ok {
+			t.Error("client credentials should not be sent in the Authorization header")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"status":0,"body":{"access_token":"ACCESS_TOKEN","refresh_token":"REFRESH_TOKEN","expires_in":10800,"userid":"363"}}`)
+	}))
+	defer server.Close()
+
+	tests := map[string]oauth2.Endpoint{
+		"Public":  Endpoint,
+		"HIPAA":   EndpointHIPAA,
+		"Unknown": {},
+	}
+
+	for name, endpoint := range tests {
+		endpoint := endpoint
+
+		t.Run(name, func(t *testing.T) {
+			config := NewConfig("client-id", "client-secret", "", nil)
+			config.Endpoint = endpoint
+			config.Endpoint.TokenURL = server.URL
+
+			token, err := config.Exchange(context.Background(), "code")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := token.AccessToken, "ACCESS_TOKEN"; got != want {
+				t.Errorf("access token = %q; want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWithingsConfig_RefreshRetries(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if requests <= 2 {
+			io.WriteString(w, `{"status":601,"error":"Too many requests"}`)
+
+			return
+		}
+
+		io.WriteString(w, `{"status":0,"body":{"access_token":"NEW_ACCESS_TOKEN","refresh_token":"NEW_REFRESH_TOKEN","expires_in":10800}}`)
+	}))
+	defer server.Close()
+
+	config := NewConfig("client-id", "client-secret", "", nil, WithRefreshRetries(2, time.Millisecond))
+	config.Endpoint.TokenURL = server.URL
+
+	token, err := config.TokenSource(context.Background(), &oauth2.Token{RefreshToken: "REFRESH_TOKEN"}).Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := token.AccessToken, "NEW_ACCESS_TOKEN"; got != want {
+		t.Errorf("access token = %q; want %q", got, want)
+	}
+
+	if got, want := requests, 3; got != want {
+		t.Errorf("requests = %d; want %d", got, want)
+	}
+
+	t.Run("Exhausted", func(t *testing.T) {
+		var requests int
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+
+			w.Header().Set("Content-Type", "application/json")
+
+			io.WriteString(w, `{"status":601,"error":"Too many requests"}`)
+		}))
+		defer server.Close()
+
+		config := NewConfig("client-id", "client-secret", "", nil, WithRefreshRetries(2, time.Millisecond))
+		config.Endpoint.TokenURL = server.URL
+
+		_, err := config.TokenSource(context.Background(), &oauth2.Token{RefreshToken: "REFRESH_TOKEN"}).Token()
+		if !isRateLimited(err) {
+			t.Errorf("error = %v; want a rate limit error", err)
+		}
+
+		if got, want := requests, 3; got != want {
+			t.Errorf("requests = %d; want %d", got, want)
+		}
+	})
+}
+
+func TestJitteredBackoff(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		max := time.Second << uint(attempt)
+		if max > maxRefreshRetryWait {
+			max = maxRefreshRetryWait
+		}
+
+		for i := 0; i < 100; i++ {
+			if wait := jitteredBackoff(0, attempt); wait < 0 || wait > max {
+				t.Fatalf("jitteredBackoff(0, %d) = %s; want between 0 and %s", attempt, wait, max)
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context/ctxhttp"
+	"golang.org/x/oauth2"
+
+	"github.com/sagikazarmark/go-withings/internal/signature"
+	"github.com/sagikazarmark/go-withings/oauth2/internal"
+)
+
+// Revoke revokes the authorization the user granted to the application.
+//
+// The token must contain the user ID (see UserID).
+// Revoking requires a signed request, so the client secret must be set in the Config.
+//
+// https://developer.withings.com/api-reference#operation/oauth2-revoke
+func (c *WithingsConfig) Revoke(ctx context.Context, token *oauth2.Token) error {
+	userID, ok := UserID(token)
+	if !ok {
+		return errors.New("oauth2: token does not contain a user ID")
+	}
+
+	ctx = c.context(ctx)
+
+	nonce, err := c.nonce(ctx)
+	if err != nil {
+		return err
+	}
+
+	v := url.Values{
+		"action":    {"revoke"},
+		"client_id": {c.ClientID},
+		"nonce":     {nonce},
+		"userid":    {strconv.FormatInt(userID, 10)},
+	}
+	v.Set("signature", signature.Compute(c.ClientSecret, v))
+
+	return postForm(ctx, c.Endpoint.TokenURL, v, nil)
+}
+
+// nonce requests a nonce from the signature endpoint
+// that lives next to the token endpoint.
+//
+// https://developer.withings.com/api-reference/#operation/signaturev2-getnonce
+func (c *WithingsConfig) nonce(ctx context.Context) (string, error) {
+	tokenURL, err := url.Parse(c.Endpoint.TokenURL)
+	if err != nil {
+		return "", err
+	}
+
+	signatureURL, err := tokenURL.Parse("signature")
+	if err != nil {
+		return "", err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	v := url.Values{
+		"action":    {"getnonce"},
+		"client_id": {c.ClientID},
+		"timestamp": {timestamp},
+	}
+	v.Set("signature", signature.Compute(c.ClientSecret, v))
+
+	var body struct {
+		Nonce string `json:"nonce"`
+	}
+
+	err = postForm(ctx, signatureURL.String(), v, &body)
+	if err != nil {
+		return "", err
+	}
+
+	return body.Nonce, nil
+}
+
+// postForm sends v to u and decodes the body of the response envelope into body (if not nil).
+// A non-zero status in the envelope is returned as an error.
+func postForm(ctx context.Context, u string, v url.Values, body interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, u, strings.NewReader(v.Encode()))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	r, err := ctxhttp.Do(ctx, internal.ContextClient(ctx), req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	rawBody, err := ioutil.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if code := r.StatusCode; code < 200 || code > 299 {
+		return fmt.Errorf("oauth2: unexpected HTTP status: %s", r.Status)
+	}
+
+	var envelope struct {
+		Status int             `json:"status"`
+		Error  string          `json:"error"`
+		Body   json.RawMessage `json:"body"`
+	}
+
+	err = json.Unmarshal(rawBody, &envelope)
+	if err != nil {
+		return err
+	}
+
+	if envelope.Status != 0 {
+		return fmt.Errorf("oauth2: %s failed with status %d: %s", v.Get("action"), envelope.Status, envelope.Error)
+	}
+
+	if body != nil && len(envelope.Body) > 0 {
+		return json.Unmarshal(envelope.Body, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestWithingsConfig_Revoke(t *testing.T) {
+	const (
+		clientID     = "client-id"
+		clientSecret = "client-secret"
+	)
+
+	var revoked bool
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/v2/signature", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("action"), "getnonce"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		want := testSignature(clientSecret, "getnonce", clientID, r.FormValue("timestamp"))
+		if got := r.FormValue("signature"); got != want {
+			t.Errorf("signature = %q; want %q", got, want)
+		}
+
+		io.WriteString(w, `{"status":0,"body":{"nonce":"NONCE"}}`)
+	})
+
+	mux.HandleFunc("/v2/oauth2", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("action"), "revoke"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		if got, want := r.FormValue("nonce"), "NONCE"; got != want {
+			t.Errorf("nonce = %q; want %q", got, want)
+		}
+
+		if got, want := r.FormValue("userid"), "363"; got != want {
+			t.Errorf("userid = %q; want %q", got, want)
+		}
+
+		if got, want := r.FormValue("signature"), testSignature(clientSecret, "revoke", clientID, "NONCE"); got != want {
+			t.Errorf("signature = %q; want %q", got, want)
+		}
+
+		revoked = true
+
+		io.WriteString(w, `{"status":0,"body":{}}`)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := &WithingsConfig{
+		Config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Endpoint: oauth2.Endpoint{
+				TokenURL:  server.URL + "/v2/oauth2",
+				AuthStyle: oauth2.AuthStyleInParams,
+			},
+		},
+	}
+
+	token := (&oauth2.Token{AccessToken: "ACCESS_TOKEN"}).WithExtra(map[string]interface{}{"userid": float64(363)})
+
+	err := config.Revoke(context.Background(), token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !revoked {
+		t.Error("token was not revoked")
+	}
+}
+
+func TestWithingsConfig_Revoke_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"status":503,"error":"Invalid Params"}`)
+	}))
+	defer server.Close()
+
+	config := &WithingsConfig{
+		Config: &oauth2.Config{
+			Endpoint: oauth2.Endpoint{TokenURL: server.URL + "/v2/oauth2"},
+		},
+	}
+
+	token := (&oauth2.Token{AccessToken: "ACCESS_TOKEN"}).WithExtra(map[string]interface{}{"userid": "363"})
+
+	if err := config.Revoke(context.Background(), token); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+// testSignature computes the expected signature of the (sorted) values independently of the signing code.
+func testSignature(secret string, values ...string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.Join(values, ",")))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,52 @@
+package oauth2
+
+// Scope is a permission requested from the user.
+//
+// https://developer.withings.com/developer-guide/v3/data-api/all-available-health-data/#scopes
+type Scope string
+
+// Scope values
+const (
+	ScopeUserInfo        Scope = "user.info"        // Access to user information.
+	ScopeUserMetrics     Scope = "user.metrics"     // Access to health data (eg. weight, blood pressure).
+	ScopeUserActivity    Scope = "user.activity"    // Access to activity and sleep data.
+	ScopeUserSleepEvents Scope = "user.sleepevents" // Access to sleep events (eg. bed in and bed out notifications).
+)
+
+var validScopeValues = map[Scope]struct{}{
+	ScopeUserInfo:        {},
+	ScopeUserMetrics:     {},
+	ScopeUserActivity:    {},
+	ScopeUserSleepEvents: {},
+}
+
+// IsValid checks if v is a valid Scope.
+func (v Scope) IsValid() bool {
+	_, ok := validScopeValues[v]
+
+	return ok
+}
+
+// AllScopes returns the list of all Scope values.
+func AllScopes() []Scope {
+	return []Scope{
+		ScopeUserInfo,
+		ScopeUserMetrics,
+		ScopeUserActivity,
+		ScopeUserSleepEvents,
+	}
+}
+
+// StringScopes converts a list of scopes to a list of strings
+// that can be used in Config.Scopes.
+//
+// AuthCodeURL takes care of joining them according to the Withings API requirements.
+func StringScopes(scopes ...Scope) []string {
+	s := make([]string, 0, len(scopes))
+
+	for _, scope := range scopes {
+		s = append(s, string(scope))
+	}
+
+	return s
+}
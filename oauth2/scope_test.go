@@ -0,0 +1,30 @@
+package oauth2
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScope(t *testing.T) {
+	t.Run("AllValid", func(t *testing.T) {
+		for _, v := range AllScopes() {
+			if !v.IsValid() {
+				t.Errorf("%s is supposed to be a valid Scope", v)
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if Scope("user.invalid").IsValid() {
+			t.Error("non existent Scope should not be valid")
+		}
+	})
+}
+
+func TestStringScopes(t *testing.T) {
+	want := []string{"user.activity", "user.metrics"}
+
+	if got := StringScopes(ScopeUserActivity, ScopeUserMetrics); !reflect.DeepEqual(got, want) {
+		t.Errorf("StringScopes() = %v; want %v", got, want)
+	}
+}
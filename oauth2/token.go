@@ -6,8 +6,12 @@ package oauth2
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"math/rand"
+	"net/http"
 	"net/url"
+	"time"
 
 	"golang.org/x/oauth2"
 )
@@ -18,6 +22,33 @@ type tokenRefresher struct {
 	ctx          context.Context // used to get HTTP requests
 	conf         *Config
 	refreshToken string
+
+	// extra contains token extras (eg. userid) carried forward to refreshed tokens.
+	extra map[string]interface{}
+
+	// onNewToken is called with every refreshed token (if not nil).
+	onNewToken func(t *oauth2.Token)
+
+	// maxRetries and retryWait configure retrying rate limited refreshes.
+	maxRetries int
+	retryWait  time.Duration
+}
+
+// carriedExtraKeys is the list of token extras carried forward to refreshed tokens
+// (in case the refresh response does not contain them).
+var carriedExtraKeys = []string{"userid", "scope"}
+
+// carriedExtras returns the extras of t that should be carried forward to refreshed tokens.
+func carriedExtras(t *oauth2.Token) map[string]interface{} {
+	extra := make(map[string]interface{}, len(carriedExtraKeys))
+
+	for _, key := range carriedExtraKeys {
+		if value := t.Extra(key); value != nil {
+			extra[key] = value
+		}
+	}
+
+	return extra
 }
 
 // WARNING: Token is not safe for concurrent access, as it
@@ -31,16 +62,85 @@ func (tf *tokenRefresher) Token() (*oauth2.Token, error) {
 		return nil, errors.New("oauth2: token expired and refresh token is not set")
 	}
 
-	tk, err := retrieveToken(tf.ctx, tf.conf, url.Values{
+	v := url.Values{
 		"action":        {"requesttoken"},
 		"grant_type":    {"refresh_token"},
 		"refresh_token": {tf.refreshToken},
-	})
+	}
+
+	tk, err := retrieveTokenWithExtras(tf.ctx, tf.conf, v, tf.extra)
+	for attempt := 0; err != nil && attempt < tf.maxRetries && isRateLimited(err); attempt++ {
+		if serr := sleep(tf.ctx, jitteredBackoff(tf.retryWait, attempt)); serr != nil {
+			return nil, err
+		}
+
+		tk, err = retrieveTokenWithExtras(tf.ctx, tf.conf, v, tf.extra)
+	}
 	if err != nil {
 		return nil, err
 	}
+	tf.extra = carriedExtras(tk)
 	if tf.refreshToken != tk.RefreshToken {
 		tf.refreshToken = tk.RefreshToken
 	}
+	if tf.onNewToken != nil {
+		tf.onNewToken(tk)
+	}
 	return tk, err
 }
+
+// statusTooManyRequests is the status returned by Withings when a request is rate limited.
+const statusTooManyRequests = 601
+
+// isRateLimited reports whether a token request failed because of rate limiting.
+func isRateLimited(err error) bool {
+	var rErr *oauth2.RetrieveError
+	if !errors.As(err, &rErr) {
+		return false
+	}
+
+	if rErr.Response != nil && rErr.Response.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	var envelope struct {
+		Status int `json:"status"`
+	}
+
+	return json.Unmarshal(rErr.Body, &envelope) == nil && envelope.Status == statusTooManyRequests
+}
+
+// maxRefreshRetryWait caps the backoff between token refresh retries.
+const maxRefreshRetryWait = time.Minute
+
+// jitteredBackoff returns a random duration between zero and an exponential backoff
+// (starting at wait, or one second if zero) for the given attempt (starting from 0).
+func jitteredBackoff(wait time.Duration, attempt int) time.Duration {
+	if wait <= 0 {
+		wait = time.Second
+	}
+
+	for i := 0; i < attempt && wait < maxRefreshRetryWait; i++ {
+		wait *= 2
+	}
+
+	if wait > maxRefreshRetryWait {
+		wait = maxRefreshRetryWait
+	}
+
+	return time.Duration(rand.Int63n(int64(wait) + 1)) // nolint: gosec
+}
+
+// sleep waits for d or until ctx is done (whichever comes first).
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	case <-timer.C:
+		return nil
+	}
+}
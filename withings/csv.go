@@ -0,0 +1,149 @@
+package withings
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// CSVColumn is a column written by Measures.WriteCSVColumns.
+type CSVColumn string
+
+// CSVColumn values
+const (
+	CSVColumnTimestamp CSVColumn = "timestamp" // Time of the measurement (RFC 3339).
+	CSVColumnGroupID   CSVColumn = "grpid"     // ID of the measure group.
+	CSVColumnCategory  CSVColumn = "category"  // Category of the measure group.
+	CSVColumnDeviceID  CSVColumn = "deviceid"  // ID of the device that captured the measure.
+	CSVColumnType      CSVColumn = "type"      // Human readable name of the measure type.
+	CSVColumnValue     CSVColumn = "value"     // Real value of the measure (see Measure.FloatValue).
+	CSVColumnUnit      CSVColumn = "unit"      // Canonical unit of the measure type (see MeasureType.Unit).
+)
+
+var validCSVColumnValues = map[CSVColumn]struct{}{
+	CSVColumnTimestamp: {},
+	CSVColumnGroupID:   {},
+	CSVColumnCategory:  {},
+	CSVColumnDeviceID:  {},
+	CSVColumnType:      {},
+	CSVColumnValue:     {},
+	CSVColumnUnit:      {},
+}
+
+// IsValid checks if v is a valid CSVColumn.
+func (v CSVColumn) IsValid() bool {
+	_, ok := validCSVColumnValues[v]
+
+	return ok
+}
+
+// DefaultCSVColumns returns the columns written by Measures.WriteCSV.
+func DefaultCSVColumns() []CSVColumn {
+	return []CSVColumn{
+		CSVColumnTimestamp,
+		CSVColumnDeviceID,
+		CSVColumnType,
+		CSVColumnValue,
+		CSVColumnUnit,
+	}
+}
+
+// WriteCSV writes the measures of the given types (or every measure if types is empty) to w in CSV format.
+//
+// The first row is a header containing the names of the columns (see DefaultCSVColumns).
+// Every other row contains a single measure.
+func (m Measures) WriteCSV(w io.Writer, types []MeasureType) error {
+	return m.WriteCSVColumns(w, types, DefaultCSVColumns())
+}
+
+// WriteCSVColumns works like WriteCSV, but writes the given columns.
+func (m Measures) WriteCSVColumns(w io.Writer, types []MeasureType, columns []CSVColumn) error {
+	if len(columns) == 0 {
+		return errors.New("need at least one column")
+	}
+
+	// Check the columns upfront, so nothing is written if they are invalid
+	// (and invalid columns are reported even if there are no measures).
+	for _, column := range columns {
+		if !column.IsValid() {
+			return fmt.Errorf("unknown CSV column: %q", column)
+		}
+	}
+
+	filter := make(map[MeasureType]struct{}, len(types))
+	for _, measureType := range types {
+		filter[measureType] = struct{}{}
+	}
+
+	cw := csv.NewWriter(w)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = string(column)
+	}
+
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, group := range m.MeasureGroups {
+		if group.TimeZone == "" {
+			group.TimeZone = m.TimeZone
+		}
+
+		for _, measure := range group.Measures {
+			if _, ok := filter[measure.Type]; len(filter) > 0 && !ok {
+				continue
+			}
+
+			record := make([]string, len(columns))
+
+			for i, column := range columns {
+				value, err := csvValue(column, group, measure)
+				if err != nil {
+					return err
+				}
+
+				record[i] = value
+			}
+
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func csvValue(column CSVColumn, group MeasureGroup, measure Measure) (string, error) {
+	switch column {
+	case CSVColumnTimestamp:
+		return group.Time().Format(time.RFC3339), nil
+
+	case CSVColumnGroupID:
+		return strconv.FormatInt(group.GroupID, 10), nil
+
+	case CSVColumnCategory:
+		return strconv.Itoa(int(group.Category)), nil
+
+	case CSVColumnDeviceID:
+		return group.DeviceID, nil
+
+	case CSVColumnType:
+		return measure.Type.String(), nil
+
+	case CSVColumnValue:
+		return strconv.FormatFloat(measure.FloatValue(), 'f', -1, 64), nil
+
+	case CSVColumnUnit:
+		return measure.Type.Unit(), nil
+	}
+
+	return "", fmt.Errorf("unknown CSV column: %q", column)
+}
@@ -0,0 +1,85 @@
+package withings
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMeasures_WriteCSV(t *testing.T) {
+	measures := Measures{
+		TimeZone: "Europe/Budapest",
+		MeasureGroups: []MeasureGroup{
+			{
+				GroupID:  1,
+				Date:     1641038400,
+				DeviceID: "abc",
+				Measures: []Measure{
+					{Value: 70500, Type: MeasureTypeWeight, Unit: -3},
+					{Value: 62, Type: MeasureTypeHeartPulse},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	err := measures.WriteCSV(&buf, []MeasureType{MeasureTypeWeight})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "timestamp,deviceid,type,value,unit\n2022-01-01T13:00:00+01:00,abc,Weight,70.5,kg\n"
+	if got := buf.String(); got != want {
+		t.Errorf("CSV = %q; want %q", got, want)
+	}
+
+	t.Run("Columns", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := measures.WriteCSVColumns(&buf, nil, []CSVColumn{CSVColumnGroupID, CSVColumnValue})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := "grpid,value\n1,70.5\n1,62\n"
+		if got := buf.String(); got != want {
+			t.Errorf("CSV = %q; want %q", got, want)
+		}
+	})
+
+	t.Run("UnknownColumn", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := measures.WriteCSVColumns(&buf, nil, []CSVColumn{CSVColumnValue, "unknown"})
+		if err == nil {
+			t.Error("expected an error for an unknown column")
+		}
+
+		if buf.Len() > 0 {
+			t.Errorf("CSV = %q; want nothing written", buf.String())
+		}
+	})
+
+	t.Run("UnknownColumnWithoutMeasures", func(t *testing.T) {
+		err := Measures{}.WriteCSVColumns(&bytes.Buffer{}, nil, []CSVColumn{"unknown"})
+		if err == nil {
+			t.Error("expected an error for an unknown column")
+		}
+	})
+}
+
+func TestCSVColumn(t *testing.T) {
+	t.Run("AllValid", func(t *testing.T) {
+		for _, v := range DefaultCSVColumns() {
+			if !v.IsValid() {
+				t.Errorf("%s is supposed to be a valid CSVColumn", v)
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if CSVColumn("invalid").IsValid() {
+			t.Error("non existent CSVColumn should not be valid")
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package withings
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// decodeHook smooths over the type inconsistencies of Withings API responses.
+//
+// Withings is not always consistent about the types in its responses
+// (eg. updatetime is documented as a string, but it's in fact an int; booleans are often sent as 0/1),
+// so fields accept every reasonable representation of their type.
+var decodeHook = mapstructure.ComposeDecodeHookFunc(
+	numericStringHookFunc,
+	boolHookFunc,
+	timeHookFunc,
+)
+
+// numericStringHookFunc decodes numeric strings into number fields.
+//
+// Empty (or blank) strings are left as is, so decoding them into a number fails.
+func numericStringHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || !isNumberKind(to.Kind()) {
+		return data, nil
+	}
+
+	str := strings.TrimSpace(data.(string))
+	if str == "" {
+		return data, nil
+	}
+
+	return parseNumber(str)
+}
+
+// boolHookFunc decodes numbers (eg. 0/1) and strings (eg. "0", "true") into bool fields.
+func boolHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if to.Kind() != reflect.Bool {
+		return data, nil
+	}
+
+	switch {
+	case isNumberKind(from.Kind()):
+		return !reflect.ValueOf(data).IsZero(), nil
+
+	case from.Kind() == reflect.String:
+		str := strings.TrimSpace(data.(string))
+		if str == "" {
+			return false, nil
+		}
+
+		if b, err := strconv.ParseBool(str); err == nil {
+			return b, nil
+		}
+
+		f, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %q as a bool", str)
+		}
+
+		return f != 0, nil
+	}
+
+	return data, nil
+}
+
+// timeHookFunc decodes unix timestamps (as numbers or numeric strings)
+// and RFC 3339 or YYYY-MM-DD formatted strings into time.Time fields.
+//
+// Empty (or blank) strings are left as is, so decoding them into a time.Time fails.
+func timeHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if to != reflect.TypeOf(time.Time{}) {
+		return data, nil
+	}
+
+	switch {
+	case isNumberKind(from.Kind()):
+		return time.Unix(reflect.ValueOf(data).Convert(reflect.TypeOf(int64(0))).Int(), 0), nil
+
+	case from.Kind() == reflect.String:
+		str := strings.TrimSpace(data.(string))
+		if str == "" {
+			return data, nil
+		}
+
+		if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+			return time.Unix(i, 0), nil
+		}
+
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, str); err == nil {
+				return t, nil
+			}
+		}
+
+		return nil, fmt.Errorf("cannot parse %q as a time", str)
+	}
+
+	return data, nil
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+// parseNumber parses str as an int64 if possible, otherwise as a float64.
+func parseNumber(str string) (interface{}, error) {
+	if i, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return i, nil
+	}
+
+	f, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse %q as a number: %w", str, err)
+	}
+
+	return f, nil
+}
@@ -0,0 +1,145 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestDecode_ActivityQuirks(t *testing.T) {
+	tests := []struct {
+		name      string
+		activity  string
+		isTracker bool
+		distance  float64
+		calories  float64
+	}{
+		{"Native", `{"date":"2022-01-01","is_tracker":true,"distance":1234.5,"calories":123.4}`, true, 1234.5, 123.4},
+		{"TrackerAsInt", `{"date":"2022-01-01","is_tracker":1}`, true, 0, 0},
+		{"NotTrackerAsInt", `{"date":"2022-01-01","is_tracker":0}`, false, 0, 0},
+		{"TrackerAsString", `{"date":"2022-01-01","is_tracker":"1"}`, true, 0, 0},
+		{"IntValues", `{"date":"2022-01-01","distance":1234,"calories":123}`, false, 1234, 123},
+		{"NumericStrings", `{"date":"2022-01-01","distance":"1234.5","calories":"123"}`, false, 1234.5, 123},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			client, mux := setup(t)
+
+			mux.HandleFunc("/v2/measure", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"status":0,"body":{"activities":[%s]}}`, test.activity)
+			})
+
+			activities, _, err := client.Measure.Getactivity(context.Background(), AllActivityFields(), ActivityGetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			activity := activities.Activities[0]
+
+			if activity.IsTracker != test.isTracker {
+				t.Errorf("IsTracker = %t; want %t", activity.IsTracker, test.isTracker)
+			}
+
+			if activity.Distance != test.distance {
+				t.Errorf("Distance = %v; want %v", activity.Distance, test.distance)
+			}
+
+			if activity.Calories != test.calories {
+				t.Errorf("Calories = %v; want %v", activity.Calories, test.calories)
+			}
+		})
+	}
+
+	t.Run("InvalidBool", func(t *testing.T) {
+		client, mux := setup(t)
+
+		mux.HandleFunc("/v2/measure", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"status":0,"body":{"activities":[{"date":"2022-01-01","is_tracker":"maybe"}]}}`)
+		})
+
+		activities, _, err := client.Measure.Getactivity(context.Background(), AllActivityFields(), ActivityGetOptions{})
+		if err == nil {
+			t.Error("decoding an invalid bool should fail")
+		}
+
+		if activities == nil {
+			t.Error("activities should not be nil on a decode error")
+		}
+	})
+}
+
+func TestDecode_Time(t *testing.T) {
+	client, mux := setup(t)
+
+	// Workout series carry timestamps as numbers, numeric strings and dates
+	mux.HandleFunc("/v2/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{"series":[{"startdate":1641038400,"enddate":"1641042000","date":"2022-01-01","modified":"2022-01-01T13:00:00Z"}]}}`)
+	})
+
+	var workoutsResp struct {
+		Body struct {
+			Series []struct {
+				Startdate time.Time `json:"startdate"`
+				Enddate   time.Time `json:"enddate"`
+				Date      time.Time `json:"date"`
+				Modified  time.Time `json:"modified"`
+			} `json:"series"`
+		} `json:"body"`
+	}
+
+	_, err := client.PostForm(context.Background(), "v2/measure", url.Values{"action": {"getworkouts"}}, &workoutsResp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(workoutsResp.Body.Series) != 1 {
+		t.Fatalf("got %d workouts; want 1", len(workoutsResp.Body.Series))
+	}
+
+	workout := workoutsResp.Body.Series[0]
+
+	tests := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"Number", workout.Startdate, time.Unix(1641038400, 0)},
+		{"NumericString", workout.Enddate, time.Unix(1641042000, 0)},
+		{"Date", workout.Date, time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"RFC3339", workout.Modified, time.Date(2022, time.January, 1, 13, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		if !test.got.Equal(test.want) {
+			t.Errorf("%s: time = %s; want %s", test.name, test.got, test.want)
+		}
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		client, mux := setup(t)
+
+		mux.HandleFunc("/v2/measure", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"status":0,"body":{"startdate":"yesterday"}}`)
+		})
+
+		var target struct {
+			Body struct {
+				Startdate time.Time `json:"startdate"`
+			} `json:"body"`
+		}
+
+		_, err := client.PostForm(context.Background(), "v2/measure", url.Values{"action": {"getworkouts"}}, &target)
+
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Errorf("error = %v; want a decode error", err)
+		}
+	})
+}
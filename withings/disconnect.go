@@ -0,0 +1,112 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenRevoker revokes the OAuth2 token of a user.
+//
+// *oauth2.WithingsConfig of this module implements it.
+type TokenRevoker interface {
+	Revoke(ctx context.Context, token *oauth2.Token) error
+}
+
+// WithTokenRevoker sets the TokenRevoker used by Disconnect.
+func WithTokenRevoker(revoker TokenRevoker) ClientOption {
+	return func(c *Client) {
+		c.tokenRevoker = revoker
+	}
+}
+
+// errTokenRevokerNotConfigured is returned by Disconnect
+// when the Client is not configured with a TokenRevoker.
+var errTokenRevokerNotConfigured = errors.New("token revoker not configured; use withings.WithTokenRevoker")
+
+// Disconnect tears down the connection between the application and the user (eg. when the user deletes their account).
+//
+// It revokes every notification subscription of the user first, then revokes the OAuth2 token of the user.
+// A failure to revoke a subscription does not prevent revoking the token:
+// errors are aggregated into a *DisconnectError.
+//
+// The Client must be authorized as the user and configured with a TokenRevoker (see WithTokenRevoker):
+// without a TokenRevoker, Disconnect fails before revoking any subscription.
+func (c *Client) Disconnect(ctx context.Context, token *oauth2.Token) error {
+	if c == nil {
+		return errClientNotInitialized
+	}
+
+	// Revoking the token requires a revoker: fail before revoking anything to avoid leaving the user half disconnected
+	if c.tokenRevoker == nil {
+		return errTokenRevokerNotConfigured
+	}
+
+	if token == nil {
+		return errors.New("token must not be nil")
+	}
+
+	var errs []error
+
+	profiles, _, err := c.Notify.List(ctx, 0)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("listing subscriptions: %w", err))
+	}
+
+	for _, profile := range profiles {
+		_, err := c.Notify.Revoke(ctx, profile.CallbackURL, profile.Appli)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("revoking subscription (appli %d, %s): %w", profile.Appli, profile.CallbackURL, err))
+		}
+	}
+
+	err = c.tokenRevoker.Revoke(ctx, token)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("revoking token: %w", err))
+	}
+
+	if errs != nil {
+		return &DisconnectError{Errors: errs}
+	}
+
+	return nil
+}
+
+// DisconnectError aggregates the errors of the failed steps of Disconnect.
+type DisconnectError struct {
+	Errors []error
+}
+
+func (e *DisconnectError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+
+	return fmt.Sprintf("disconnecting user failed: %s", strings.Join(msgs, "; "))
+}
+
+// Is reports whether any of the aggregated errors matches target.
+func (e *DisconnectError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first aggregated error that matches target.
+func (e *DisconnectError) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,114 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	withingsoauth2 "github.com/sagikazarmark/go-withings/oauth2"
+)
+
+var _ TokenRevoker = (*withingsoauth2.WithingsConfig)(nil)
+
+type tokenRevokerFunc func(ctx context.Context, token *oauth2.Token) error
+
+func (fn tokenRevokerFunc) Revoke(ctx context.Context, token *oauth2.Token) error {
+	return fn(ctx, token)
+}
+
+func TestClient_Disconnect(t *testing.T) {
+	var actions []string
+
+	token := &oauth2.Token{AccessToken: "ACCESS_TOKEN"}
+
+	revoker := tokenRevokerFunc(func(ctx context.Context, got *oauth2.Token) error {
+		actions = append(actions, "revoke token")
+
+		if got != token {
+			t.Errorf("token = %+v; want %+v", got, token)
+		}
+
+		return nil
+	})
+
+	client, mux := setup(t, WithTokenRevoker(revoker))
+
+	mux.HandleFunc("/notify", func(w http.ResponseWriter, r *http.Request) {
+		switch action := r.FormValue("action"); action {
+		case "list":
+			fmt.Fprint(w, `{"status":0,"body":{"profiles":[`+
+				`{"appli":1,"callbackurl":"https://example.com/callback"},`+
+				`{"appli":16,"callbackurl":"https://example.com/callback"}`+
+				`]}}`)
+
+		case "revoke":
+			actions = append(actions, fmt.Sprintf("revoke %s", r.FormValue("appli")))
+
+			if r.FormValue("appli") == "1" {
+				fmt.Fprint(w, `{"status":2555,"error":"An unknown error occurred"}`)
+
+				return
+			}
+
+			fmt.Fprint(w, `{"status":0,"body":{}}`)
+
+		default:
+			t.Errorf("unexpected action: %s", action)
+		}
+	})
+
+	err := client.Disconnect(context.Background(), token)
+
+	var derr *DisconnectError
+	if !errors.As(err, &derr) {
+		t.Fatalf("error should be a disconnect error, got %v", err)
+	}
+
+	if got, want := len(derr.Errors), 1; got != want {
+		t.Errorf("got %d errors; want %d", got, want)
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Status != StatusUnknownError {
+		t.Errorf("error should contain an error response, got %v", err)
+	}
+
+	if want := []string{"revoke 1", "revoke 16", "revoke token"}; !reflect.DeepEqual(actions, want) {
+		t.Errorf("actions = %v; want %v", actions, want)
+	}
+
+	t.Run("RevokeTokenError", func(t *testing.T) {
+		revokeErr := errors.New("revoke failed")
+
+		client, mux := setup(t, WithTokenRevoker(tokenRevokerFunc(func(ctx context.Context, token *oauth2.Token) error {
+			return revokeErr
+		})))
+
+		mux.HandleFunc("/notify", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"status":0,"body":{"profiles":[]}}`)
+		})
+
+		err := client.Disconnect(context.Background(), token)
+		if !errors.Is(err, revokeErr) {
+			t.Errorf("error should match %v, got %v", revokeErr, err)
+		}
+	})
+}
+
+func TestClient_Disconnect_NoTokenRevoker(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/notify", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("subscriptions should not be touched without a token revoker (action: %s)", r.FormValue("action"))
+	})
+
+	err := client.Disconnect(context.Background(), &oauth2.Token{AccessToken: "ACCESS_TOKEN"})
+	if !errors.Is(err, errTokenRevokerNotConfigured) {
+		t.Errorf("error should match %v, got %v", errTokenRevokerNotConfigured, err)
+	}
+}
@@ -0,0 +1,194 @@
+package withings
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors matching classes of Withings API status codes.
+//
+// Use errors.Is to check whether an error returned by the client belongs to one of these classes:
+//
+//	if errors.Is(err, withings.ErrRateLimited) {
+//		// back off
+//	}
+var (
+	ErrInvalidToken        = errors.New("authentication failed")
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrInvalidParams       = errors.New("invalid params")
+	ErrRateLimited         = errors.New("too many requests")
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+)
+
+// Common status codes returned by the Withings API.
+//
+// Withings API docs: https://developer.withings.com/api-reference#section/Response-status
+const (
+	StatusOK                 = 0    // Operation was successful.
+	StatusUnauthorized       = 214  // Unauthorized.
+	StatusInvalidUserID      = 247  // The userid provided is absent, or incorrect.
+	StatusNoSuchSubscription = 286  // No such subscription was found.
+	StatusInvalidCallbackURL = 293  // The callback URL is either absent or incorrect.
+	StatusInvalidSignature   = 342  // The signature is invalid.
+	StatusInvalidToken       = 401  // Authentication failed (eg. invalid or expired access token).
+	StatusInvalidParams      = 503  // Invalid params.
+	StatusTimeout            = 522  // Timeout.
+	StatusTooManyRequests    = 601  // Too many requests.
+	StatusWrongAction        = 2554 // Wrong action or wrong webservice.
+	StatusUnknownError       = 2555 // An unknown error occurred.
+)
+
+// IsTokenExpired reports whether err was caused by an invalid or expired access token.
+//
+// Integrations can use it to refresh the token and retry the request.
+func IsTokenExpired(err error) bool {
+	return errors.Is(err, ErrInvalidToken)
+}
+
+// An ErrorResponse reports an error caused by an API request.
+//
+// Withings API docs: https://developer.withings.com/api-reference#section/Response-status
+type ErrorResponse struct {
+	Response *Response // Response that caused this error
+
+	// Status code returned from the Withings API.
+	Status int
+
+	// Message is the error message returned from the Withings API (if any).
+	Message string
+}
+
+func (r *ErrorResponse) Error() string {
+	msg := fmt.Sprintf("status %d", r.Status)
+	if r.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, r.Message)
+	}
+
+	if r.Response != nil && r.Response.HttpResponse != nil && r.Response.HttpResponse.Request != nil {
+		req := r.Response.HttpResponse.Request
+
+		return fmt.Sprintf("%v %v: %s", req.Method, req.URL, msg)
+	}
+
+	return msg
+}
+
+// Is reports whether the status code of the error belongs to the class represented by target.
+func (r *ErrorResponse) Is(target error) bool {
+	class := statusClass(r.Status)
+
+	return class != nil && class == target // nolint: errorlint
+}
+
+// A RateLimitError reports a request rejected by the HTTP layer (eg. a proxy in front of the API)
+// because of too many requests, as opposed to the API itself returning StatusTooManyRequests in an ErrorResponse.
+//
+// It matches ErrRateLimited, so errors.Is(err, ErrRateLimited) covers both cases.
+type RateLimitError struct {
+	Response *Response // Response that caused this error
+
+	// StatusCode is the HTTP status code of the response (ie. 429).
+	StatusCode int
+}
+
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("HTTP %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+
+	if e.Response != nil && e.Response.HttpResponse != nil && e.Response.HttpResponse.Request != nil {
+		req := e.Response.HttpResponse.Request
+
+		return fmt.Sprintf("%v %v: %s", req.Method, req.URL, msg)
+	}
+
+	return msg
+}
+
+// Unwrap returns ErrRateLimited.
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// A DecodeError reports an error caused by an unexpected response payload.
+//
+// It contains the raw response body, so the payload can be inspected (eg. logged).
+type DecodeError struct {
+	Response *Response // Response that caused this error
+
+	// Body is the raw (decompressed) response body.
+	Body []byte
+
+	// Err is the underlying decoding error.
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decoding response: %v", e.Err)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// A PaginationError reports an error that occurred while following pagination
+// after some pages were already fetched successfully.
+//
+// Methods returning a PaginationError also return the merged results of the successful pages,
+// so the caller can checkpoint them and resume fetching from Offset.
+type PaginationError struct {
+	// Offset is the offset of the page that could not be fetched.
+	Offset int
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *PaginationError) Error() string {
+	return fmt.Sprintf("fetching page at offset %d: %v", e.Offset, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PaginationError) Unwrap() error {
+	return e.Err
+}
+
+// statusClass returns the sentinel error matching the class of a Withings status code.
+//
+// Withings API docs: https://developer.withings.com/api-reference#section/Response-status
+func statusClass(status int) error {
+	switch status {
+	case 100, 101, 102, 200, StatusInvalidToken:
+		return ErrInvalidToken
+
+	case StatusUnauthorized, 277, 2553:
+		return ErrUnauthorized
+
+	case StatusTooManyRequests:
+		return ErrRateLimited
+
+	case StatusTimeout, StatusUnknownError:
+		return ErrUpstreamUnavailable
+	}
+
+	if _, ok := invalidParamsStatuses[status]; ok {
+		return ErrInvalidParams
+	}
+
+	return nil
+}
+
+var invalidParamsStatuses = map[int]struct{}{
+	201: {}, 202: {}, 203: {}, 204: {}, 205: {}, 206: {}, 207: {}, 208: {}, 209: {}, 210: {},
+	211: {}, 212: {}, 213: {}, 216: {}, 217: {}, 218: {}, 220: {}, 221: {}, 223: {}, 225: {},
+	227: {}, 228: {}, 229: {}, 230: {}, 234: {}, 235: {}, 236: {}, 238: {}, 240: {}, 241: {},
+	242: {}, 243: {}, 244: {}, 245: {}, 246: {}, 247: {}, 248: {}, 249: {}, 250: {}, 252: {},
+	254: {}, 260: {}, 261: {}, 262: {}, 263: {}, 264: {}, 265: {}, 266: {}, 267: {}, 271: {},
+	272: {}, 275: {}, 276: {}, 283: {}, 284: {}, 285: {}, 286: {}, 287: {}, 288: {}, 290: {},
+	293: {}, 294: {}, 295: {}, 297: {}, 300: {}, 301: {}, 302: {}, 303: {}, 304: {}, 321: {},
+	323: {}, 324: {}, 325: {}, 326: {}, 327: {}, 328: {}, 329: {}, 330: {}, 331: {}, 332: {},
+	333: {}, 334: {}, 335: {}, 336: {}, 337: {}, 338: {}, 339: {}, 340: {}, 341: {}, 342: {},
+	343: {}, 344: {}, 345: {}, 346: {}, 347: {}, 348: {}, 349: {}, 350: {}, 351: {}, 352: {},
+	353: {}, 380: {}, 381: {}, 382: {}, 400: {}, 501: {}, 502: {}, 503: {}, 504: {}, 505: {},
+	506: {}, 509: {}, 510: {}, 511: {}, 523: {}, 532: {}, 3017: {}, 3018: {}, 3019: {},
+}
@@ -0,0 +1,156 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestErrorResponse_Is(t *testing.T) {
+	tests := []struct {
+		status int
+		target error
+	}{
+		{100, ErrInvalidToken},
+		{401, ErrInvalidToken},
+		{214, ErrUnauthorized},
+		{2553, ErrUnauthorized},
+		{201, ErrInvalidParams},
+		{503, ErrInvalidParams},
+		{3019, ErrInvalidParams},
+		{601, ErrRateLimited},
+		{522, ErrUpstreamUnavailable},
+		{2555, ErrUpstreamUnavailable},
+	}
+
+	sentinels := []error{ErrInvalidToken, ErrUnauthorized, ErrInvalidParams, ErrRateLimited, ErrUpstreamUnavailable}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(fmt.Sprintf("%d", test.status), func(t *testing.T) {
+			var err error = &ErrorResponse{Status: test.status}
+
+			for _, sentinel := range sentinels {
+				if got, want := errors.Is(err, sentinel), sentinel == test.target; got != want { // nolint: errorlint
+					t.Errorf("errors.Is(%q) = %t; want %t", sentinel, got, want)
+				}
+			}
+		})
+	}
+
+	t.Run("Unknown", func(t *testing.T) {
+		var err error = &ErrorResponse{Status: 9999}
+
+		for _, sentinel := range sentinels {
+			if errors.Is(err, sentinel) {
+				t.Errorf("unknown status should not match %q", sentinel)
+			}
+		}
+	})
+}
+
+func TestClient_Do_ErrorResponse(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":601,"error":"Too many requests","body":{}}`)
+	})
+
+	_, resp, err := client.Measure.Getmeas(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{})
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("error = %v; want *ErrorResponse", err)
+	}
+
+	if errResp.Status != StatusTooManyRequests {
+		t.Errorf("status = %d; want %d", errResp.Status, StatusTooManyRequests)
+	}
+
+	if got, want := errResp.Message, "Too many requests"; got != want {
+		t.Errorf("message = %q; want %q", got, want)
+	}
+
+	if !errors.Is(err, ErrRateLimited) {
+		t.Error("error should match ErrRateLimited")
+	}
+
+	if resp == nil || resp.Status != 601 {
+		t.Fatalf("response status should be populated")
+	}
+
+	if got, want := resp.Error, "Too many requests"; got != want {
+		t.Errorf("response error = %q; want %q", got, want)
+	}
+}
+
+func TestClient_Do_RateLimitError(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, resp, err := client.Measure.Getmeas(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{})
+
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("error = %v; want *RateLimitError", err)
+	}
+
+	if got, want := rateLimitErr.StatusCode, http.StatusTooManyRequests; got != want {
+		t.Errorf("status code = %d; want %d", got, want)
+	}
+
+	var errResp *ErrorResponse
+	if errors.As(err, &errResp) {
+		t.Error("HTTP rate limiting should not be reported as an API error")
+	}
+
+	if !errors.Is(err, ErrRateLimited) {
+		t.Error("error should match ErrRateLimited")
+	}
+
+	if resp == nil || !resp.RateLimited || resp.Status != StatusOK {
+		t.Errorf("response should be rate limited without an API status")
+	}
+}
+
+func TestClient_Do_DecodeError(t *testing.T) {
+	client, mux := setup(t)
+
+	const body = `{"status":0,"body":{"updatetime":"not a number"}}`
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	})
+
+	_, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"getmeas"}}, new(getmeasResponse))
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("error = %v; want *DecodeError", err)
+	}
+
+	if got := string(decodeErr.Body); got != body {
+		t.Errorf("body = %q; want %q", got, body)
+	}
+}
+
+func TestIsTokenExpired(t *testing.T) {
+	if !IsTokenExpired(fmt.Errorf("wrapped: %w", &ErrorResponse{Status: StatusInvalidToken})) {
+		t.Error("invalid token error should be reported as expired token")
+	}
+
+	if IsTokenExpired(&ErrorResponse{Status: StatusInvalidParams}) {
+		t.Error("invalid params error should not be reported as expired token")
+	}
+
+	if IsTokenExpired(errors.New("something went wrong")) {
+		t.Error("generic error should not be reported as expired token")
+	}
+}
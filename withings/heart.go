@@ -1,7 +1,223 @@
 package withings
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
 // HeartService handles communication with the heart related
 // methods of the Withings API.
 //
 // Withings API docs: https://developer.withings.com/api-reference/#tag/heart
 type HeartService service
+
+type heartListResponse struct {
+	Body HeartMeasurements `json:"body"`
+}
+
+// HeartMeasurements is the response from the List API call.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/heartv2-list
+type HeartMeasurements struct {
+	Series []HeartMeasurement `json:"series"`
+}
+
+// HeartMeasurement is an ECG recording (and an optional blood pressure measurement) taken by a device.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/heartv2-list
+type HeartMeasurement struct {
+	DeviceID  string      `json:"deviceid"`
+	Model     DeviceModel `json:"model"`
+	HeartRate int         `json:"heart_rate"`
+	Timestamp int64       `json:"timestamp"`
+	Timezone  string      `json:"timezone"`
+	Modified  int64       `json:"modified"`
+
+	ECG           ECG           `json:"ecg"`
+	BloodPressure BloodPressure `json:"bloodpressure"`
+}
+
+// ECG contains the metadata of an ECG recording.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/heartv2-list
+type ECG struct {
+	// SignalID can be used to retrieve the raw signal using HeartService.Get.
+	SignalID int `json:"signalid"`
+
+	// Atrial fibrillation classification (0: negative, 1: positive, 2: inconclusive).
+	AFib int `json:"afib"`
+}
+
+// BloodPressure is a blood pressure measurement (in mmHg).
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/heartv2-list
+type BloodPressure struct {
+	Diastole int `json:"diastole"`
+	Systole  int `json:"systole"`
+}
+
+// List returns a list of ECG recordings and Afib classification for a given period of time.
+//
+// The endpoint does not support filtering by LastUpdate: an error is returned if it is set in opts.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/heartv2-list
+func (s *HeartService) List(ctx context.Context, opts MeasureGetOptions) (*HeartMeasurements, *Response, error) {
+	if s == nil || s.client == nil {
+		return nil, nil, errClientNotInitialized
+	}
+
+	if err := s.client.requireScope(ctx, scopeUserMetrics); err != nil {
+		return nil, nil, err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if !opts.LastUpdate.IsZero() {
+		return nil, nil, errors.New("LastUpdate is not supported by the heart list endpoint")
+	}
+
+	const urlPath = "v2/heart"
+
+	form := url.Values{
+		"action": {"list"},
+	}
+
+	DateRange{Start: opts.StartDate, End: opts.EndDate}.EncodeValues(form, DateModeUnix)
+
+	if opts.Offset > 0 {
+		form.Add("offset", fmt.Sprintf("%d", opts.Offset))
+	}
+
+	listResp := new(heartListResponse)
+
+	resp, err := s.client.PostForm(ctx, urlPath, form, listResp)
+
+	return &listResp.Body, resp, err
+}
+
+// WearPosition is the position of the device on the body of the user during a measurement.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/heartv2-get
+type WearPosition int
+
+// WearPosition values
+const (
+	WearPositionRightWrist  WearPosition = 0  // Right wrist.
+	WearPositionLeftWrist   WearPosition = 1  // Left wrist.
+	WearPositionRightArm    WearPosition = 2  // Right arm.
+	WearPositionLeftArm     WearPosition = 3  // Left arm.
+	WearPositionRightFoot   WearPosition = 4  // Right foot.
+	WearPositionLeftFoot    WearPosition = 5  // Left foot.
+	WearPositionBetweenLegs WearPosition = 6  // Between legs.
+	WearPositionLeftBody    WearPosition = 8  // Left part of the body.
+	WearPositionRightBody   WearPosition = 9  // Right part of the body.
+	WearPositionLeftLeg     WearPosition = 10 // Left leg.
+	WearPositionRightLeg    WearPosition = 11 // Right leg.
+	WearPositionTorso       WearPosition = 12 // Torso.
+	WearPositionLeftHand    WearPosition = 13 // Left hand.
+	WearPositionRightHand   WearPosition = 14 // Right hand.
+)
+
+var wearPositionNames = map[WearPosition]string{
+	WearPositionRightWrist:  "Right Wrist",
+	WearPositionLeftWrist:   "Left Wrist",
+	WearPositionRightArm:    "Right Arm",
+	WearPositionLeftArm:     "Left Arm",
+	WearPositionRightFoot:   "Right Foot",
+	WearPositionLeftFoot:    "Left Foot",
+	WearPositionBetweenLegs: "Between Legs",
+	WearPositionLeftBody:    "Left Part Of The Body",
+	WearPositionRightBody:   "Right Part Of The Body",
+	WearPositionLeftLeg:     "Left Leg",
+	WearPositionRightLeg:    "Right Leg",
+	WearPositionTorso:       "Torso",
+	WearPositionLeftHand:    "Left Hand",
+	WearPositionRightHand:   "Right Hand",
+}
+
+// IsValid checks if v is a valid WearPosition.
+func (v WearPosition) IsValid() bool {
+	_, ok := wearPositionNames[v]
+
+	return ok
+}
+
+// String returns the human readable name of v.
+func (v WearPosition) String() string {
+	if name, ok := wearPositionNames[v]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("WearPosition(%d)", int(v))
+}
+
+type heartGetResponse struct {
+	Body ECGSignal `json:"body"`
+}
+
+// ECGSignal is the raw signal of an ECG recording.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/heartv2-get
+type ECGSignal struct {
+	// Signal contains the raw ECG samples (in micro-volts).
+	Signal []int `json:"signal"`
+
+	// Sampling frequency of the signal (in Hz).
+	SamplingFrequency int `json:"sampling_frequency"`
+
+	// Where the user is wearing the device.
+	WearPosition WearPosition `json:"wearposition"`
+}
+
+// Samples returns the ECG samples scaled to milli-volts.
+func (s ECGSignal) Samples() []float64 {
+	samples := make([]float64, len(s.Signal))
+
+	for i, v := range s.Signal {
+		samples[i] = float64(v) / 1000
+	}
+
+	return samples
+}
+
+// Duration returns the duration of the recording based on the sampling frequency.
+//
+// Duration returns zero if the sampling frequency is unknown.
+func (s ECGSignal) Duration() time.Duration {
+	if s.SamplingFrequency <= 0 {
+		return 0
+	}
+
+	return time.Duration(len(s.Signal)) * time.Second / time.Duration(s.SamplingFrequency)
+}
+
+// Get returns the raw signal of an ECG recording.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/heartv2-get
+func (s *HeartService) Get(ctx context.Context, signalID int) (*ECGSignal, *Response, error) {
+	if s == nil || s.client == nil {
+		return nil, nil, errClientNotInitialized
+	}
+
+	if err := s.client.requireScope(ctx, scopeUserMetrics); err != nil {
+		return nil, nil, err
+	}
+
+	const urlPath = "v2/heart"
+
+	form := url.Values{
+		"action":   {"get"},
+		"signalid": {fmt.Sprintf("%d", signalID)},
+	}
+
+	getResp := new(heartGetResponse)
+
+	resp, err := s.client.PostForm(ctx, urlPath, form, getResp)
+
+	return &getResp.Body, resp, err
+}
@@ -0,0 +1,115 @@
+package withings
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHeartService_List(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v2/heart", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("action"), "list"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{"series":[{"deviceid":"abc","model":44,"ecg":{"signalid":123,"afib":0},"bloodpressure":{"diastole":80,"systole":120},"heart_rate":60,"timestamp":1000}],"more":false,"offset":0}}`)
+	})
+
+	measurements, _, err := client.Heart.List(context.Background(), MeasureGetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &HeartMeasurements{
+		Series: []HeartMeasurement{
+			{
+				DeviceID:      "abc",
+				Model:         44,
+				HeartRate:     60,
+				Timestamp:     1000,
+				ECG:           ECG{SignalID: 123},
+				BloodPressure: BloodPressure{Diastole: 80, Systole: 120},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(measurements, want) {
+		t.Errorf("measurements = %+v; want %+v", measurements, want)
+	}
+
+	t.Run("LastUpdate", func(t *testing.T) {
+		_, _, err := client.Heart.List(context.Background(), MeasureGetOptions{LastUpdate: time.Unix(1000, 0)})
+		if err == nil {
+			t.Error("expected an error for the unsupported LastUpdate filter")
+		}
+	})
+}
+
+func TestHeartService_Get(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v2/heart", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("action"), "get"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		if got, want := r.FormValue("signalid"), "123"; got != want {
+			t.Errorf("signalid = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{"signal":[1,-2,3],"sampling_frequency":500,"wearposition":1}}`)
+	})
+
+	signal, _, err := client.Heart.Get(context.Background(), 123)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &ECGSignal{
+		Signal:            []int{1, -2, 3},
+		SamplingFrequency: 500,
+		WearPosition:      WearPositionLeftWrist,
+	}
+
+	if !reflect.DeepEqual(signal, want) {
+		t.Errorf("signal = %+v; want %+v", signal, want)
+	}
+}
+
+func TestECGSignal_Samples(t *testing.T) {
+	signal := ECGSignal{
+		Signal:            []int{-250, 0, 1500, 500},
+		SamplingFrequency: 2,
+	}
+
+	if got, want := signal.Samples(), []float64{-0.25, 0, 1.5, 0.5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Samples() = %v; want %v", got, want)
+	}
+
+	if got, want := signal.Duration(), 2*time.Second; got != want {
+		t.Errorf("Duration() = %s; want %s", got, want)
+	}
+}
+
+func TestWearPosition(t *testing.T) {
+	if !WearPositionTorso.IsValid() {
+		t.Error("WearPositionTorso is supposed to be valid")
+	}
+
+	if WearPosition(7).IsValid() {
+		t.Error("non existent WearPosition should not be valid")
+	}
+
+	if got, want := WearPositionLeftWrist.String(), "Left Wrist"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+
+	if got, want := WearPosition(7).String(), "WearPosition(7)"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+}
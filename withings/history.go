@@ -0,0 +1,211 @@
+package withings
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// WeightSample is a weight measurement.
+type WeightSample struct {
+	Time      time.Time
+	Kilograms float64
+}
+
+// WeightHistory returns the weight measurements of the user between start and end sorted by time.
+//
+// It follows pagination and unpacks measure groups, so the returned list is complete.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
+func (s *MeasureService) WeightHistory(ctx context.Context, start time.Time, end time.Time) ([]WeightSample, error) {
+	measures, err := s.measureHistory(ctx, MeasureTypeWeight, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]WeightSample, 0, len(measures))
+
+	for _, measure := range measures {
+		samples = append(samples, WeightSample{
+			Time:      measure.Time,
+			Kilograms: measure.FloatValue(),
+		})
+	}
+
+	return samples, nil
+}
+
+// SpO2Sample is a blood oxygen saturation measurement.
+type SpO2Sample struct {
+	Time    time.Time
+	Percent float64
+}
+
+// SpO2History returns the SpO2 measurements of the user between start and end sorted by time.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
+func (s *MeasureService) SpO2History(ctx context.Context, start time.Time, end time.Time) ([]SpO2Sample, error) {
+	measures, err := s.measureHistory(ctx, MeasureTypeSpO2, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]SpO2Sample, 0, len(measures))
+
+	for _, measure := range measures {
+		samples = append(samples, SpO2Sample{
+			Time:    measure.Time,
+			Percent: measure.FloatValue(),
+		})
+	}
+
+	return samples, nil
+}
+
+// TemperatureSample is a temperature measurement.
+type TemperatureSample struct {
+	Time    time.Time
+	Celsius float64
+}
+
+// TemperatureHistory returns the temperature measurements of the user between start and end sorted by time.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
+func (s *MeasureService) TemperatureHistory(ctx context.Context, start time.Time, end time.Time) ([]TemperatureSample, error) {
+	return s.temperatureHistory(ctx, MeasureTypeTemp, start, end)
+}
+
+// BodyTemperatureHistory returns the body temperature measurements of the user between start and end sorted by time.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
+func (s *MeasureService) BodyTemperatureHistory(ctx context.Context, start time.Time, end time.Time) ([]TemperatureSample, error) {
+	return s.temperatureHistory(ctx, MeasureTypeBodyTemp, start, end)
+}
+
+// SkinTemperatureHistory returns the skin temperature measurements of the user between start and end sorted by time.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
+func (s *MeasureService) SkinTemperatureHistory(ctx context.Context, start time.Time, end time.Time) ([]TemperatureSample, error) {
+	return s.temperatureHistory(ctx, MeasureTypeSkinTemp, start, end)
+}
+
+func (s *MeasureService) temperatureHistory(ctx context.Context, measureType MeasureType, start time.Time, end time.Time) ([]TemperatureSample, error) {
+	measures, err := s.measureHistory(ctx, measureType, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]TemperatureSample, 0, len(measures))
+
+	for _, measure := range measures {
+		samples = append(samples, TemperatureSample{
+			Time:    measure.Time,
+			Celsius: measure.FloatValue(),
+		})
+	}
+
+	return samples, nil
+}
+
+// timedMeasure is a measure along with the time it was taken.
+type timedMeasure struct {
+	Time time.Time
+
+	Measure
+}
+
+// measureHistory returns the real measures of a given type between start and end sorted by time.
+//
+// It follows pagination and unpacks measure groups, so the returned list is complete.
+func (s *MeasureService) measureHistory(ctx context.Context, measureType MeasureType, start time.Time, end time.Time) ([]timedMeasure, error) {
+	opts := MeasureGetOptions{
+		StartDate: start,
+		EndDate:   end,
+	}
+
+	measures, _, err := s.GetmeasAll(ctx, []MeasureType{measureType}, MeasureCategoryRealMeasure, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var timedMeasures []timedMeasure
+
+	for _, group := range measures.MeasureGroups {
+		for _, measure := range group.Measures {
+			if measure.Type != measureType {
+				continue
+			}
+
+			timedMeasures = append(timedMeasures, timedMeasure{
+				Time:    group.Time(),
+				Measure: measure,
+			})
+		}
+	}
+
+	sort.SliceStable(timedMeasures, func(i, j int) bool {
+		return timedMeasures[i].Time.Before(timedMeasures[j].Time)
+	})
+
+	return timedMeasures, nil
+}
+
+// BloodPressureSample is a blood pressure measurement.
+type BloodPressureSample struct {
+	Time time.Time
+
+	// Systolic and diastolic blood pressure (in mmHg).
+	Systolic  float64
+	Diastolic float64
+}
+
+// BloodPressureHistory returns the blood pressure measurements of the user between start and end sorted by time.
+//
+// Only measure groups containing both systolic and diastolic values are returned.
+// It follows pagination and unpacks measure groups, so the returned list is complete.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
+func (s *MeasureService) BloodPressureHistory(ctx context.Context, start time.Time, end time.Time) ([]BloodPressureSample, error) {
+	opts := MeasureGetOptions{
+		StartDate: start,
+		EndDate:   end,
+	}
+
+	measureTypes := []MeasureType{MeasureTypeSystolicBP, MeasureTypeDiastolicBP}
+
+	measures, _, err := s.GetmeasAll(ctx, measureTypes, MeasureCategoryRealMeasure, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var samples []BloodPressureSample
+
+	for _, group := range measures.MeasureGroups {
+		var (
+			sample                    = BloodPressureSample{Time: group.Time()}
+			hasSystolic, hasDiastolic bool
+		)
+
+		for _, measure := range group.Measures {
+			switch measure.Type {
+			case MeasureTypeSystolicBP:
+				sample.Systolic = measure.FloatValue()
+				hasSystolic = true
+
+			case MeasureTypeDiastolicBP:
+				sample.Diastolic = measure.FloatValue()
+				hasDiastolic = true
+			}
+		}
+
+		if hasSystolic && hasDiastolic {
+			samples = append(samples, sample)
+		}
+	}
+
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Time.Before(samples[j].Time)
+	})
+
+	return samples, nil
+}
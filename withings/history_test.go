@@ -0,0 +1,92 @@
+package withings
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMeasureService_WeightHistory(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("meastype"), "1"; got != want {
+			t.Errorf("meastype = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{"measuregrps":[
+			{"grpid":2,"date":1641042000,"measures":[{"value":70500,"type":1,"unit":-3}]},
+			{"grpid":1,"date":1641038400,"measures":[{"value":71,"type":1,"unit":0}]}
+		]}}`)
+	})
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	samples, err := client.Measure.WeightHistory(context.Background(), start, start.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(samples) != 2 {
+		t.Fatalf("got %d samples; want 2", len(samples))
+	}
+
+	if got, want := samples[0].Kilograms, 71.0; got != want {
+		t.Errorf("first sample = %v kg; want %v kg", got, want)
+	}
+
+	if got, want := samples[1].Kilograms, 70.5; got != want {
+		t.Errorf("second sample = %v kg; want %v kg", got, want)
+	}
+}
+
+func TestMeasureService_BloodPressureHistory(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{"measuregrps":[
+			{"grpid":1,"date":1641038400,"measures":[{"value":120,"type":10,"unit":0},{"value":80,"type":9,"unit":0}]},
+			{"grpid":2,"date":1641042000,"measures":[{"value":125,"type":10,"unit":0}]}
+		]}}`)
+	})
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	samples, err := client.Measure.BloodPressureHistory(context.Background(), start, start.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(samples) != 1 {
+		t.Fatalf("got %d samples; want 1", len(samples))
+	}
+
+	if samples[0].Systolic != 120 || samples[0].Diastolic != 80 {
+		t.Errorf("sample = %+v; want 120/80", samples[0])
+	}
+}
+
+func TestMeasureService_SkinTemperatureHistory(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("meastype"), "73"; got != want {
+			t.Errorf("meastype = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{"measuregrps":[{"grpid":1,"date":1641038400,"measures":[{"value":3350,"type":73,"unit":-2}]}]}}`)
+	})
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	samples, err := client.Measure.SkinTemperatureHistory(context.Background(), start, start.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(samples) != 1 || samples[0].Celsius != 33.5 {
+		t.Errorf("samples = %+v; want a single 33.5 °C sample", samples)
+	}
+}
@@ -0,0 +1,212 @@
+package withings
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// HRSource identifies the origin of an HRSample.
+type HRSource string
+
+// HRSource values
+const (
+	HRSourceIntradayActivity HRSource = "intradayactivity" // Intraday activity heart rate measurement.
+	HRSourceActivity         HRSource = "activity"         // Daily average heart rate.
+	HRSourceWorkout          HRSource = "workout"          // Average heart rate during a workout.
+	HRSourceSleep            HRSource = "sleep"            // Heart rate measured during sleep.
+)
+
+// HRSample is a single heart rate data point.
+//
+// It provides a unified representation of heart rate data returned by different endpoints,
+// so data from multiple sources can be merged into a single timeline.
+type HRSample struct {
+	Time   time.Time
+	BPM    int
+	Source HRSource
+}
+
+// HRSamples returns the heart rate samples of the series in chronological order.
+//
+// Intervals without heart rate data are skipped.
+func (a IntradayActivities) HRSamples() ([]HRSample, error) {
+	samples, err := a.Sorted()
+	if err != nil {
+		return nil, err
+	}
+
+	hrSamples := make([]HRSample, 0, len(samples))
+
+	for _, sample := range samples {
+		if sample.HeartRate == 0 {
+			continue
+		}
+
+		hrSamples = append(hrSamples, HRSample{
+			Time:   sample.Time,
+			BPM:    sample.HeartRate,
+			Source: HRSourceIntradayActivity,
+		})
+	}
+
+	return hrSamples, nil
+}
+
+// HRSamples returns the average heart rate of each activity anchored to the start of the day.
+//
+// Activities without heart rate data are skipped.
+func (a Activities) HRSamples() ([]HRSample, error) {
+	hrSamples := make([]HRSample, 0, len(a.Activities))
+
+	for _, activity := range a.Activities {
+		if activity.HRAverage == 0 {
+			continue
+		}
+
+		day, err := activity.Day()
+		if err != nil {
+			return nil, err
+		}
+
+		hrSamples = append(hrSamples, HRSample{
+			Time:   day,
+			BPM:    activity.HRAverage,
+			Source: HRSourceActivity,
+		})
+	}
+
+	return hrSamples, nil
+}
+
+// HRSamples returns the average heart rate of each workout anchored to the start of the workout.
+//
+// Workouts without heart rate data are skipped.
+func (w Workouts) HRSamples() []HRSample {
+	hrSamples := make([]HRSample, 0, len(w.Series))
+
+	for _, workout := range w.Series {
+		if workout.Data.HrAverage == 0 {
+			continue
+		}
+
+		hrSamples = append(hrSamples, HRSample{
+			Time:   time.Unix(workout.Startdate, 0),
+			BPM:    workout.Data.HrAverage,
+			Source: HRSourceWorkout,
+		})
+	}
+
+	return hrSamples
+}
+
+// HRSamples returns the heart rate samples measured during sleep in chronological order.
+func (s Sleep) HRSamples() ([]HRSample, error) {
+	var hrSamples []HRSample
+
+	for _, segment := range s.Series {
+		for key, bpm := range segment.HR {
+			timestamp, err := strconv.ParseInt(key, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sleep heart rate timestamp %q: %w", key, err)
+			}
+
+			hrSamples = append(hrSamples, HRSample{
+				Time:   time.Unix(timestamp, 0),
+				BPM:    bpm,
+				Source: HRSourceSleep,
+			})
+		}
+	}
+
+	sort.Slice(hrSamples, func(i, j int) bool {
+		return hrSamples[i].Time.Before(hrSamples[j].Time)
+	})
+
+	return hrSamples, nil
+}
+
+// MergeHRSamples merges heart rate samples from multiple sources into a single timeline.
+//
+// The returned samples are sorted by time.
+// Samples with the same time and BPM reported by different sources are only included once
+// (the first occurrence wins).
+func MergeHRSamples(samples ...[]HRSample) []HRSample {
+	type key struct {
+		time int64
+		bpm  int
+	}
+
+	var merged []HRSample
+
+	seen := map[key]struct{}{}
+
+	for _, s := range samples {
+		for _, sample := range s {
+			k := key{sample.Time.UnixNano(), sample.BPM}
+
+			if _, ok := seen[k]; ok {
+				continue
+			}
+
+			seen[k] = struct{}{}
+
+			merged = append(merged, sample)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(merged[j].Time)
+	})
+
+	return merged
+}
+
+// HRZones is the time spent in each heart rate zone
+// (0: light, 1: moderate, 2: intense, 3: maximal).
+type HRZones [4]time.Duration
+
+// Total returns the time spent in any of the heart rate zones.
+func (z HRZones) Total() time.Duration {
+	var total time.Duration
+
+	for _, d := range z {
+		total += d
+	}
+
+	return total
+}
+
+// Percent returns the percentage (0-100) of time spent in a heart rate zone.
+//
+// Percent returns zero for unknown zones and when no time was spent in any of the zones.
+func (z HRZones) Percent(zone int) float64 {
+	total := z.Total()
+
+	if zone < 0 || zone >= len(z) || total == 0 {
+		return 0
+	}
+
+	return float64(z[zone]) / float64(total) * 100
+}
+
+// HRZoneBreakdown returns the time spent in each heart rate zone during the day.
+func (a Activity) HRZoneBreakdown() HRZones {
+	return HRZones{
+		time.Duration(a.HRZone0) * time.Second,
+		time.Duration(a.HRZone1) * time.Second,
+		time.Duration(a.HRZone2) * time.Second,
+		time.Duration(a.HRZone3) * time.Second,
+	}
+}
+
+// HRZoneBreakdown returns the time spent in each heart rate zone during the workout.
+func (w Workout) HRZoneBreakdown() HRZones {
+	return HRZones{
+		time.Duration(w.Data.HrZone0) * time.Second,
+		time.Duration(w.Data.HrZone1) * time.Second,
+		time.Duration(w.Data.HrZone2) * time.Second,
+		time.Duration(w.Data.HrZone3) * time.Second,
+	}
+}
@@ -0,0 +1,95 @@
+package withings
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeHRSamples(t *testing.T) {
+	intraday, err := IntradayActivities{
+		Series: map[string]IntradayActivity{
+			"1000120": {HeartRate: 70},
+			"1000060": {HeartRate: 0},
+			"1000000": {HeartRate: 65},
+		},
+	}.HRSamples()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workouts := Workouts{
+		Series: []Workout{
+			{Startdate: 1000000, Data: WorkoutData{HrAverage: 65}},
+			{Startdate: 1000090, Data: WorkoutData{HrAverage: 120}},
+		},
+	}.HRSamples()
+
+	merged := MergeHRSamples(intraday, workouts)
+
+	want := []HRSample{
+		{Time: time.Unix(1000000, 0), BPM: 65, Source: HRSourceIntradayActivity},
+		{Time: time.Unix(1000090, 0), BPM: 120, Source: HRSourceWorkout},
+		{Time: time.Unix(1000120, 0), BPM: 70, Source: HRSourceIntradayActivity},
+	}
+
+	if len(merged) != len(want) {
+		t.Fatalf("merged %d samples; want %d", len(merged), len(want))
+	}
+
+	for i := range want {
+		if !merged[i].Time.Equal(want[i].Time) || merged[i].BPM != want[i].BPM || merged[i].Source != want[i].Source {
+			t.Errorf("sample %d = %+v; want %+v", i, merged[i], want[i])
+		}
+	}
+}
+
+func TestActivities_HRSamples(t *testing.T) {
+	samples, err := Activities{
+		Activities: []Activity{
+			{Date: "2022-01-02", Timezone: "Europe/Budapest", HRAverage: 72},
+			{Date: "2022-01-03", Timezone: "Europe/Budapest"},
+		},
+	}.HRSamples()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(samples) != 1 {
+		t.Fatalf("got %d samples; want 1", len(samples))
+	}
+
+	if got, want := samples[0].Time.UTC(), time.Date(2022, time.January, 1, 23, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("time = %s; want %s", got, want)
+	}
+}
+
+func TestHRZones(t *testing.T) {
+	activity := Activity{HRZone0: 600, HRZone1: 300, HRZone2: 60, HRZone3: 240}
+	workout := Workout{Data: WorkoutData{HrZone0: 600, HrZone1: 300, HrZone2: 60, HrZone3: 240}}
+
+	for name, zones := range map[string]HRZones{"Activity": activity.HRZoneBreakdown(), "Workout": workout.HRZoneBreakdown()} {
+		zones := zones
+
+		t.Run(name, func(t *testing.T) {
+			if got, want := zones.Total(), 20*time.Minute; got != want {
+				t.Errorf("Total() = %s; want %s", got, want)
+			}
+
+			for zone, want := range []float64{50, 25, 5, 20} {
+				if got := zones.Percent(zone); got != want {
+					t.Errorf("Percent(%d) = %v; want %v", zone, got, want)
+				}
+			}
+
+			if got := zones.Percent(4); got != 0 {
+				t.Errorf("Percent(4) = %v; want 0", got)
+			}
+		})
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		if got := (HRZones{}).Percent(0); got != 0 {
+			t.Errorf("Percent(0) = %v; want 0", got)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package withings
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// RequestLogEntry describes a request sent by the Client and its outcome.
+//
+// It does not contain form values to avoid leaking secrets (eg. tokens or signatures) into logs.
+type RequestLogEntry struct {
+	Method string
+	URL    string
+
+	// Action is the value of the action form field (if any).
+	Action string
+
+	// FormKeys is the sorted list of form fields sent in the request body.
+	FormKeys []string
+
+	// StatusCode is the HTTP status code of the response (zero if the request failed).
+	StatusCode int
+
+	// Duration is the time it took to send the request and receive the response headers.
+	Duration time.Duration
+
+	// Err is the error returned by the HTTP client (if any).
+	Err error
+}
+
+func newRequestLogEntry(req *http.Request, resp *http.Response, duration time.Duration, err error) RequestLogEntry {
+	entry := RequestLogEntry{
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Duration: duration,
+		Err:      err,
+	}
+
+	if resp != nil {
+		entry.StatusCode = resp.StatusCode
+	}
+
+	form := requestForm(req)
+
+	entry.Action = form.Get("action")
+
+	for key := range form {
+		entry.FormKeys = append(entry.FormKeys, key)
+	}
+
+	sort.Strings(entry.FormKeys)
+
+	return entry
+}
+
+// requestForm returns the form sent in the request body without consuming the body.
+func requestForm(req *http.Request) url.Values {
+	if req.GetBody == nil || req.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer body.Close()
+
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil
+	}
+
+	form, _ := url.ParseQuery(string(b))
+
+	return form
+}
@@ -0,0 +1,54 @@
+package withings
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestClient_Logger(t *testing.T) {
+	var entries []RequestLogEntry
+
+	client, mux := setup(t, WithLogger(func(entry RequestLogEntry) {
+		entries = append(entries, entry)
+	}))
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	form := url.Values{
+		"action":    {"getmeas"},
+		"signature": {"secret"},
+	}
+
+	_, err := client.PostForm(context.Background(), "measure", form, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("got %d log entries; want 1", len(entries))
+	}
+
+	entry := entries[0]
+
+	if got, want := entry.Method, http.MethodPost; got != want {
+		t.Errorf("method = %q; want %q", got, want)
+	}
+
+	if got, want := entry.Action, "getmeas"; got != want {
+		t.Errorf("action = %q; want %q", got, want)
+	}
+
+	if got, want := entry.FormKeys, []string{"action", "signature"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("form keys = %v; want %v", got, want)
+	}
+
+	if got, want := entry.StatusCode, http.StatusOK; got != want {
+		t.Errorf("status code = %d; want %d", got, want)
+	}
+}
@@ -2,11 +2,17 @@ package withings
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/mitchellh/mapstructure"
 )
 
 // MeasureService handles communication with the measure related
@@ -18,6 +24,8 @@ type MeasureService service
 // MeasureGetOptions specifies parameters for various Measure related operations
 // that support date based filters and/or pagination.
 //
+// Dates are sent to the API as unix timestamps.
+//
 // Withings API docs: https://developer.withings.com/api-reference#tag/measure
 type MeasureGetOptions struct {
 	// Use StartDate and EndDate for a date range query.
@@ -33,6 +41,132 @@ type MeasureGetOptions struct {
 
 	// Offset retrieves the next batch from the resultset.
 	Offset int
+
+	// MaxPages limits the number of requests sent by methods that follow pagination
+	// (eg. GetmeasAll). Zero means no limit.
+	MaxPages int
+
+	// DeviceID limits the results to data recorded by a single device.
+	//
+	// The Withings API does not support filtering by device,
+	// so the filter is applied after fetching the data:
+	// pagination (More and Offset) still reflects the unfiltered result set.
+	DeviceID string
+
+	// AllowUnknownMeasureTypes sends measure types unknown to this package to the API
+	// instead of dropping them.
+	//
+	// It makes it possible to request measure types released by Withings
+	// before this package supports them.
+	AllowUnknownMeasureTypes bool
+}
+
+// Validate checks that the date filters are not used in conflicting ways.
+func (o MeasureGetOptions) Validate() error {
+	if !o.LastUpdate.IsZero() && (!o.StartDate.IsZero() || !o.EndDate.IsZero()) {
+		return errors.New("LastUpdate is mutually exclusive with StartDate/EndDate")
+	}
+
+	if o.StartDate.IsZero() != o.EndDate.IsZero() {
+		return errors.New("StartDate and EndDate must be set together")
+	}
+
+	return nil
+}
+
+func (o MeasureGetOptions) dateRange() DateRange {
+	return DateRange{
+		Start:      o.StartDate,
+		End:        o.EndDate,
+		LastUpdate: o.LastUpdate,
+	}
+}
+
+// DateMode is the format used to send dates to the Withings API.
+//
+// Different endpoints expect dates in different formats:
+// some of them accept unix timestamps, others expect YYYY-MM-DD dates.
+type DateMode int
+
+// DateMode values
+const (
+	DateModeUnix DateMode = iota // Unix timestamps (startdate, enddate).
+	DateModeYMD                  // YYYY-MM-DD dates (startdateymd, enddateymd).
+)
+
+// DateRange specifies date based filters for operations that support them.
+type DateRange struct {
+	// Use Start and End for a date range query.
+	//
+	// Mutually exclusive with LastUpdate!
+	Start time.Time
+	End   time.Time
+
+	// Use LastUpdate to query new values.
+	// LastUpdate is always sent as a unix timestamp.
+	//
+	// Mutually exclusive with Start and End!
+	LastUpdate time.Time
+}
+
+// Validate checks that the date filters are not used in conflicting ways.
+func (r DateRange) Validate() error {
+	if !r.LastUpdate.IsZero() && (!r.Start.IsZero() || !r.End.IsZero()) {
+		return errors.New("LastUpdate is mutually exclusive with Start/End")
+	}
+
+	if r.Start.IsZero() != r.End.IsZero() {
+		return errors.New("Start and End must be set together")
+	}
+
+	return nil
+}
+
+// EncodeValues adds the date range to form using the date format of mode.
+func (r DateRange) EncodeValues(form url.Values, mode DateMode) {
+	if !r.LastUpdate.IsZero() {
+		form.Add("lastupdate", fmt.Sprintf("%d", r.LastUpdate.Unix()))
+
+		return
+	}
+
+	if r.Start.IsZero() || r.End.IsZero() {
+		return
+	}
+
+	switch mode {
+	case DateModeYMD:
+		form.Add("startdateymd", r.Start.Format("2006-01-02"))
+		form.Add("enddateymd", r.End.Format("2006-01-02"))
+
+	default:
+		form.Add("startdate", fmt.Sprintf("%d", r.Start.Unix()))
+		form.Add("enddate", fmt.Sprintf("%d", r.End.Unix()))
+	}
+}
+
+// ActivityGetOptions specifies parameters for operations that filter by day
+// (Getactivity, Getworkouts and Sleep GetSummary) and support pagination.
+//
+// Dates are sent to the API in YYYY-MM-DD format.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getactivity
+type ActivityGetOptions struct {
+	DateRange
+
+	// Offset retrieves the next batch from the resultset.
+	Offset int
+
+	// MaxPages limits the number of requests sent by methods that follow pagination
+	// (eg. GetactivityAll). Zero means no limit.
+	MaxPages int
+
+	// DeviceID limits the results to data recorded by a single device.
+	//
+	// The Withings API does not support filtering by device,
+	// so the filter is applied after fetching the data:
+	// pagination (More and Offset) still reflects the unfiltered result set.
+	DeviceID string
 }
 
 // MeasureType is is a metric that Withings devices track.
@@ -66,38 +200,138 @@ const (
 	MeasureTypeAtrialFib      MeasureType = 139 // Atrial fibrillation result from PPG
 )
 
-var validMeasureTypeValues = map[MeasureType]struct{}{
-	MeasureTypeWeight:         {},
-	MeasureTypeHeight:         {},
-	MeasureTypeFatFreeMass:    {},
-	MeasureTypeFatRatio:       {},
-	MeasureTypeFatMassWeight:  {},
-	MeasureTypeDiastolicBP:    {},
-	MeasureTypeSystolicBP:     {},
-	MeasureTypeHeartPulse:     {},
-	MeasureTypeTemp:           {},
-	MeasureTypeSpO2:           {},
-	MeasureTypeBodyTemp:       {},
-	MeasureTypeSkinTemp:       {},
-	MeasureTypeMuscleMass:     {},
-	MeasureTypeHydration:      {},
-	MeasureTypeBoneMass:       {},
-	MeasureTypePWaveVel:       {},
-	MeasureTypeVO2Max:         {},
-	MeasureTypeQRSInterval:    {},
-	MeasureTypePRInterval:     {},
-	MeasureTypeQTInterval:     {},
-	MeasureTypeCorrQTInterval: {},
-	MeasureTypeAtrialFib:      {},
+// MeasureTypeInfo describes a MeasureType.
+type MeasureTypeInfo struct {
+	// Name is the human readable name of the measure type (see MeasureType.String).
+	Name string
+
+	// Unit is the canonical unit of the measure type (see MeasureType.Unit).
+	// Empty for unitless measure types.
+	Unit string
+
+	// Description is a short description of the measure type.
+	Description string
+}
+
+// measureTypeInfos is the single source of truth for known measure types.
+//
+// Withings does not provide an API for discovering measure types, so the list is maintained manually.
+var measureTypeInfos = map[MeasureType]MeasureTypeInfo{
+	MeasureTypeWeight:         {Name: "Weight", Unit: "kg", Description: "Body weight."},
+	MeasureTypeHeight:         {Name: "Height", Unit: "m", Description: "Body height."},
+	MeasureTypeFatFreeMass:    {Name: "Fat Free Mass", Unit: "kg", Description: "Weight of the body without fat."},
+	MeasureTypeFatRatio:       {Name: "Fat Ratio", Unit: "%", Description: "Percentage of body fat."},
+	MeasureTypeFatMassWeight:  {Name: "Fat Mass Weight", Unit: "kg", Description: "Weight of body fat."},
+	MeasureTypeDiastolicBP:    {Name: "Diastolic Blood Pressure", Unit: "mmHg", Description: "Diastolic blood pressure."},
+	MeasureTypeSystolicBP:     {Name: "Systolic Blood Pressure", Unit: "mmHg", Description: "Systolic blood pressure."},
+	MeasureTypeHeartPulse:     {Name: "Heart Pulse", Unit: "bpm", Description: "Heart rate."},
+	MeasureTypeTemp:           {Name: "Temperature", Unit: "°C", Description: "Temperature."},
+	MeasureTypeSpO2:           {Name: "SpO2", Unit: "%", Description: "Blood oxygen saturation."},
+	MeasureTypeBodyTemp:       {Name: "Body Temperature", Unit: "°C", Description: "Body temperature."},
+	MeasureTypeSkinTemp:       {Name: "Skin Temperature", Unit: "°C", Description: "Skin temperature."},
+	MeasureTypeMuscleMass:     {Name: "Muscle Mass", Unit: "kg", Description: "Weight of muscles."},
+	MeasureTypeHydration:      {Name: "Hydration", Unit: "kg", Description: "Weight of water in the body."},
+	MeasureTypeBoneMass:       {Name: "Bone Mass", Unit: "kg", Description: "Weight of bones."},
+	MeasureTypePWaveVel:       {Name: "Pulse Wave Velocity", Unit: "m/s", Description: "Pulse wave velocity (speed of the blood pressure pulse through the arteries)."},
+	MeasureTypeVO2Max:         {Name: "VO2 Max", Unit: "ml/min/kg", Description: "Maximal oxygen consumption (the body's ability to consume oxygen)."},
+	MeasureTypeQRSInterval:    {Name: "QRS Interval", Unit: "ms", Description: "QRS interval duration based on ECG signal."},
+	MeasureTypePRInterval:     {Name: "PR Interval", Unit: "ms", Description: "PR interval duration based on ECG signal."},
+	MeasureTypeQTInterval:     {Name: "QT Interval", Unit: "ms", Description: "QT interval duration based on ECG signal."},
+	MeasureTypeCorrQTInterval: {Name: "Corrected QT Interval", Unit: "ms", Description: "Corrected QT interval duration based on ECG signal."},
+	MeasureTypeAtrialFib:      {Name: "Atrial Fibrillation", Unit: "", Description: "Atrial fibrillation result from PPG."},
+}
+
+// MeasureTypeInfos returns the description of every known MeasureType.
+//
+// It can be used by tooling (eg. to render a list of measure types).
+// The returned map is a copy, so modifying it has no effect on the package.
+func MeasureTypeInfos() map[MeasureType]MeasureTypeInfo {
+	infos := make(map[MeasureType]MeasureTypeInfo, len(measureTypeInfos))
+
+	for measureType, info := range measureTypeInfos {
+		infos[measureType] = info
+	}
+
+	return infos
+}
+
+// Info returns the description of v.
+func (v MeasureType) Info() (MeasureTypeInfo, bool) {
+	info, ok := measureTypeInfos[v]
+
+	return info, ok
 }
 
 // IsValid checks if v is a valid MeasureType.
 func (v MeasureType) IsValid() bool {
-	_, ok := validMeasureTypeValues[v]
+	_, ok := measureTypeInfos[v]
 
 	return ok
 }
 
+// String returns the human readable name of v.
+func (v MeasureType) String() string {
+	if info, ok := measureTypeInfos[v]; ok {
+		return info.Name
+	}
+
+	return fmt.Sprintf("MeasureType(%d)", int(v))
+}
+
+// MeasureTypeSymbol is a MeasureType that is JSON encoded as its symbolic name (eg. "Weight")
+// instead of its numeric value.
+//
+// It can be used to store measure types in a stable, human readable form.
+// Decoding accepts both the symbolic name and the numeric value.
+type MeasureTypeSymbol MeasureType
+
+// MarshalJSON encodes v as its symbolic name.
+func (v MeasureTypeSymbol) MarshalJSON() ([]byte, error) {
+	if !MeasureType(v).IsValid() {
+		return nil, fmt.Errorf("invalid MeasureType: %d", int(v))
+	}
+
+	return json.Marshal(MeasureType(v).String())
+}
+
+// UnmarshalJSON decodes a symbolic name or a numeric value and rejects unknown values.
+func (v *MeasureTypeSymbol) UnmarshalJSON(data []byte) error {
+	var name string
+
+	if err := json.Unmarshal(data, &name); err == nil {
+		for measureType, info := range measureTypeInfos {
+			if info.Name == name {
+				*v = MeasureTypeSymbol(measureType)
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("unknown MeasureType name: %q", name)
+	}
+
+	var i int
+
+	if err := json.Unmarshal(data, &i); err != nil {
+		return err
+	}
+
+	if !MeasureType(i).IsValid() {
+		return fmt.Errorf("invalid MeasureType: %d", i)
+	}
+
+	*v = MeasureTypeSymbol(i)
+
+	return nil
+}
+
+// Unit returns the canonical unit of v (eg. "kg", "bpm", "%").
+//
+// Unit returns an empty string for unitless and unknown measure types.
+func (v MeasureType) Unit() string {
+	return measureTypeInfos[v].Unit
+}
+
 // AllMeasureTypes returns the list of all MeasureType values.
 func AllMeasureTypes() []MeasureType {
 	return []MeasureType{
@@ -126,6 +360,25 @@ func AllMeasureTypes() []MeasureType {
 	}
 }
 
+// CommonMeasureTypes returns the list of everyday MeasureType values
+// (weight, height, fat ratio, blood pressure, heart pulse, SpO2 and temperature).
+//
+// It can be used instead of AllMeasureTypes to avoid requesting rarely populated measure types
+// (eg. ECG intervals) that bloat the response.
+func CommonMeasureTypes() []MeasureType {
+	return []MeasureType{
+		MeasureTypeWeight,
+		MeasureTypeHeight,
+		MeasureTypeFatRatio,
+		MeasureTypeDiastolicBP,
+		MeasureTypeSystolicBP,
+		MeasureTypeHeartPulse,
+		MeasureTypeSpO2,
+		MeasureTypeTemp,
+		MeasureTypeBodyTemp,
+	}
+}
+
 // MeasureCategory differentiates between real measurements and user objectives.
 //
 // Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
@@ -149,6 +402,52 @@ func (v MeasureCategory) IsValid() bool {
 	return ok
 }
 
+// MeasureAttrib describes how a measure group was captured.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
+type MeasureAttrib int
+
+// MeasureAttrib values
+const (
+	MeasureAttribDevice          MeasureAttrib = 0  // Captured by a device and known to belong to the user.
+	MeasureAttribDeviceAmbiguous MeasureAttrib = 1  // Captured by a device, but may belong to other users as well.
+	MeasureAttribManual          MeasureAttrib = 2  // Entered manually for the user.
+	MeasureAttribManualCreation  MeasureAttrib = 4  // Entered manually during user creation (may not be accurate).
+	MeasureAttribAuto            MeasureAttrib = 5  // Computed automatically from multiple measures (Blood Pressure Monitor only).
+	MeasureAttribConfirmed       MeasureAttrib = 7  // Confirmed by the user (eg. a detected activity).
+	MeasureAttribDeviceAlt       MeasureAttrib = 8  // Same as MeasureAttribDevice.
+	MeasureAttribGuided          MeasureAttrib = 15 // Performed in guided conditions (Nerve Health Score).
+	MeasureAttribGuidedEDA       MeasureAttrib = 17 // Performed in guided conditions (Nerve Health Score and Electrodermal Activity Score).
+)
+
+var measureAttribNames = map[MeasureAttrib]string{
+	MeasureAttribDevice:          "Device",
+	MeasureAttribDeviceAmbiguous: "Device (Ambiguous)",
+	MeasureAttribManual:          "Manual",
+	MeasureAttribManualCreation:  "Manual (User Creation)",
+	MeasureAttribAuto:            "Auto",
+	MeasureAttribConfirmed:       "Confirmed",
+	MeasureAttribDeviceAlt:       "Device",
+	MeasureAttribGuided:          "Guided",
+	MeasureAttribGuidedEDA:       "Guided (EDA)",
+}
+
+// IsValid checks if v is a valid MeasureAttrib.
+func (v MeasureAttrib) IsValid() bool {
+	_, ok := measureAttribNames[v]
+
+	return ok
+}
+
+// String returns the human readable name of v.
+func (v MeasureAttrib) String() string {
+	if name, ok := measureAttribNames[v]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("MeasureAttrib(%d)", int(v))
+}
+
 type getmeasResponse struct {
 	Body Measures `json:"body"`
 }
@@ -157,23 +456,192 @@ type getmeasResponse struct {
 //
 // Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
 type Measures struct {
-	UpdateTime    int            `json:"updatetime"` // Note: spec says string, but it's in fact an int
+	UpdateTime    int            `json:"updatetime"` // Note: spec says string, but it's in fact an int (both are accepted)
 	TimeZone      string         `json:"timezone"`
 	MeasureGroups []MeasureGroup `json:"measuregrps"`
 }
 
+// Updated returns the time of the last update as a time.Time in the user's timezone.
+//
+// UpdateTime is a unix timestamp (regardless of what the spec says).
+// It can be used as LastUpdate in subsequent queries to fetch new values.
+func (m Measures) Updated() time.Time {
+	return time.Unix(int64(m.UpdateTime), 0).In(loadLocationOrUTC(m.TimeZone))
+}
+
+// UpdatedAt is an alias of Updated.
+func (m Measures) UpdatedAt() time.Time {
+	return m.Updated()
+}
+
+// Group returns the measure group with the given ID.
+//
+// The returned group points into MeasureGroups, so modifications are reflected in m.
+func (m Measures) Group(grpid int64) (*MeasureGroup, bool) {
+	for i := range m.MeasureGroups {
+		if m.MeasureGroups[i].GroupID == grpid {
+			return &m.MeasureGroups[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// Latest returns the most recent measure of the given type along with the time it was taken.
+//
+// User objectives are ignored. The last return value is false if there is no such measure.
+func (m Measures) Latest(measureType MeasureType) (Measure, time.Time, bool) {
+	var (
+		latest     Measure
+		latestDate int
+		found      bool
+	)
+
+	for _, group := range m.MeasureGroups {
+		if group.Category == MeasureCategoryUserObjective || (found && group.Date <= latestDate) {
+			continue
+		}
+
+		for _, measure := range group.Measures {
+			if measure.Type == measureType {
+				latest, latestDate, found = measure, group.Date, true
+
+				break
+			}
+		}
+	}
+
+	if !found {
+		return Measure{}, time.Time{}, false
+	}
+
+	return latest, time.Unix(int64(latestDate), 0).In(loadLocationOrUTC(m.TimeZone)), true
+}
+
+// LatestAll returns the most recent measure of each measure type found in m.
+//
+// User objectives are ignored.
+func (m Measures) LatestAll() map[MeasureType]Measure {
+	latest := map[MeasureType]Measure{}
+	dates := map[MeasureType]int{}
+
+	for _, group := range m.MeasureGroups {
+		if group.Category == MeasureCategoryUserObjective {
+			continue
+		}
+
+		for _, measure := range group.Measures {
+			if date, ok := dates[measure.Type]; ok && group.Date <= date {
+				continue
+			}
+
+			latest[measure.Type] = measure
+			dates[measure.Type] = group.Date
+		}
+	}
+
+	return latest
+}
+
+// FilterByType returns a copy of m with only the measures of the given types.
+//
+// Measure groups without any of the given measure types are dropped.
+func (m Measures) FilterByType(types ...MeasureType) Measures {
+	wanted := make(map[MeasureType]struct{}, len(types))
+	for _, measureType := range types {
+		wanted[measureType] = struct{}{}
+	}
+
+	filtered := m
+	filtered.MeasureGroups = make([]MeasureGroup, 0, len(m.MeasureGroups))
+
+	for _, group := range m.MeasureGroups {
+		measures := make([]Measure, 0, len(group.Measures))
+
+		for _, measure := range group.Measures {
+			if _, ok := wanted[measure.Type]; ok {
+				measures = append(measures, measure)
+			}
+		}
+
+		if len(measures) == 0 {
+			continue
+		}
+
+		group.Measures = measures
+		filtered.MeasureGroups = append(filtered.MeasureGroups, group)
+	}
+
+	return filtered
+}
+
+// RealMeasures returns a copy of m containing only real measure groups.
+//
+// Useful when both real measures and user objectives were fetched (eg. with GetmeasCategories).
+func (m Measures) RealMeasures() Measures {
+	return m.filterCategory(MeasureCategoryRealMeasure)
+}
+
+// Objectives returns a copy of m containing only user objective groups.
+func (m Measures) Objectives() Measures {
+	return m.filterCategory(MeasureCategoryUserObjective)
+}
+
+func (m Measures) filterCategory(category MeasureCategory) Measures {
+	filtered := m
+	filtered.MeasureGroups = make([]MeasureGroup, 0, len(m.MeasureGroups))
+
+	for _, group := range m.MeasureGroups {
+		if group.Category == category {
+			filtered.MeasureGroups = append(filtered.MeasureGroups, group)
+		}
+	}
+
+	return filtered
+}
+
+// setTimeZone copies the timezone of the response to each measure group.
+func (m *Measures) setTimeZone() {
+	for i := range m.MeasureGroups {
+		m.MeasureGroups[i].TimeZone = m.TimeZone
+	}
+}
+
 // Measures are returned in groups.
 //
 // Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
 type MeasureGroup struct {
 	GroupID   int64           `json:"grpid"`
-	Attrib    int             `json:"attrib"`
+	Attrib    MeasureAttrib   `json:"attrib"`
 	Date      int             `json:"date"`
 	CreatedAt int             `json:"created"`
 	Category  MeasureCategory `json:"category"`
 	DeviceID  string          `json:"deviceid"`
 	Measures  []Measure       `json:"measures"`
 	Comment   string          `json:"comment"` // Deprecated
+
+	// TimeZone is the timezone of the user (copied from Measures.TimeZone by Getmeas).
+	TimeZone string `json:"-"`
+}
+
+// Time returns the time of the measurement in the user's timezone.
+//
+// UTC is used if the timezone is unknown or cannot be loaded.
+func (g MeasureGroup) Time() time.Time {
+	return time.Unix(int64(g.Date), 0).In(loadLocationOrUTC(g.TimeZone))
+}
+
+// Created returns the time the measure group was created in the user's timezone.
+//
+// UTC is used if the timezone is unknown or cannot be loaded.
+func (g MeasureGroup) Created() time.Time {
+	return time.Unix(int64(g.CreatedAt), 0).In(loadLocationOrUTC(g.TimeZone))
+}
+
+// IsManualEntry returns true if the measure group was entered manually
+// (instead of being captured by a device).
+func (g MeasureGroup) IsManualEntry() bool {
+	return g.Attrib == MeasureAttribManual || g.Attrib == MeasureAttribManualCreation
 }
 
 // Measure is an individual data point.
@@ -188,16 +656,38 @@ type Measure struct {
 	FW    int         `json:"fw"`   // Deprecated
 }
 
+// FloatValue returns the real value of the measure.
+//
+// Value is an integer that needs to be multiplied by ten to the power of Unit
+// to get the real value (eg. value=70500 and unit=-3 means 70.5).
+func (m Measure) FloatValue() float64 {
+	return float64(m.Value) * math.Pow10(m.Unit)
+}
+
 // Getmeas provides measures stored on a specific date.
 //
 // Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
 func (s *MeasureService) Getmeas(ctx context.Context, measureTypes []MeasureType, category MeasureCategory, opts MeasureGetOptions) (*Measures, *Response, error) {
+	if s == nil || s.client == nil {
+		return nil, nil, errClientNotInitialized
+	}
+
+	if err := s.client.requireScope(ctx, scopeUserMetrics); err != nil {
+		return nil, nil, err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	// validate category first because it requires less effort
 	if !category.IsValid() {
 		return nil, nil, errors.New("invalid category")
 	}
 
-	measureTypes = filterValidMeasureTypeValues(measureTypes)
+	if !opts.AllowUnknownMeasureTypes {
+		measureTypes = filterValidMeasureTypeValues(measureTypes)
+	}
 
 	if len(measureTypes) == 0 {
 		return nil, nil, errors.New("need at least one measure type")
@@ -216,12 +706,7 @@ func (s *MeasureService) Getmeas(ctx context.Context, measureTypes []MeasureType
 		form.Add("meastypes", strings.Join(measureTypesToString(measureTypes), ","))
 	}
 
-	if !opts.LastUpdate.IsZero() {
-		form.Add("lastupdate", fmt.Sprintf("%d", opts.LastUpdate.Unix()))
-	} else if !opts.StartDate.IsZero() && !opts.EndDate.IsZero() {
-		form.Add("startdate", fmt.Sprintf("%d", opts.StartDate.Unix()))
-		form.Add("enddate", fmt.Sprintf("%d", opts.EndDate.Unix()))
-	}
+	opts.dateRange().EncodeValues(form, DateModeUnix)
 
 	if opts.Offset > 0 {
 		form.Add("offset", fmt.Sprintf("%d", opts.Offset))
@@ -230,8 +715,30 @@ func (s *MeasureService) Getmeas(ctx context.Context, measureTypes []MeasureType
 	measuresResp := new(getmeasResponse)
 
 	resp, err := s.client.PostForm(ctx, urlPath, form, measuresResp)
+	if err != nil {
+		return &measuresResp.Body, resp, err
+	}
+
+	measuresResp.Body.setTimeZone()
 
-	return &measuresResp.Body, resp, err
+	if opts.DeviceID != "" {
+		measuresResp.Body.filterDevice(opts.DeviceID)
+	}
+
+	return &measuresResp.Body, resp, nil
+}
+
+// filterDevice drops measure groups not recorded by the device.
+func (m *Measures) filterDevice(deviceID string) {
+	groups := m.MeasureGroups[:0]
+
+	for _, group := range m.MeasureGroups {
+		if group.DeviceID == deviceID {
+			groups = append(groups, group)
+		}
+	}
+
+	m.MeasureGroups = groups
 }
 
 func filterValidMeasureTypeValues(values []MeasureType) []MeasureType {
@@ -258,6 +765,265 @@ func measureTypesToString(measureTypes []MeasureType) []string {
 	return s
 }
 
+// GetmeasAll calls Getmeas repeatedly, following pagination until all pages are fetched,
+// and returns the merged results.
+//
+// The number of requests can be limited by setting MaxPages in opts.
+// If the limit is reached, the returned Response indicates that there is more data to fetch.
+//
+// If fetching a page fails after some pages were fetched successfully,
+// the merged results of those pages are returned along with a *PaginationError
+// containing the offset to resume from.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
+func (s *MeasureService) GetmeasAll(ctx context.Context, measureTypes []MeasureType, category MeasureCategory, opts MeasureGetOptions) (*Measures, *Response, error) {
+	var all *Measures
+
+	p := Paginator{Offset: opts.Offset, MaxPages: opts.MaxPages}
+
+	resp, err := p.Paginate(ctx, func(ctx context.Context, offset int) (*Response, error) {
+		opts.Offset = offset
+
+		measures, resp, err := s.Getmeas(ctx, measureTypes, category, opts)
+		if err != nil {
+			return resp, err
+		}
+
+		if all == nil {
+			all = measures
+		} else {
+			all.MeasureGroups = append(all.MeasureGroups, measures.MeasureGroups...)
+		}
+
+		return resp, nil
+	})
+
+	return all, resp, err
+}
+
+// GetmeasCategories calls GetmeasAll for each category and returns the merged results.
+//
+// Each measure group carries its category, so the results can be separated afterwards.
+// The returned Response is the response of the last request.
+//
+// If fetching a category fails, the merged results fetched so far
+// (including the partial results of the failed category) are returned along with the error.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
+func (s *MeasureService) GetmeasCategories(ctx context.Context, measureTypes []MeasureType, categories []MeasureCategory, opts MeasureGetOptions) (*Measures, *Response, error) {
+	if len(categories) == 0 {
+		return nil, nil, errors.New("need at least one category")
+	}
+
+	var (
+		all  *Measures
+		resp *Response
+	)
+
+	for _, category := range categories {
+		var (
+			measures *Measures
+			err      error
+		)
+
+		measures, resp, err = s.GetmeasAll(ctx, measureTypes, category, opts)
+
+		if measures != nil {
+			if all == nil {
+				all = measures
+			} else {
+				all.MeasureGroups = append(all.MeasureGroups, measures.MeasureGroups...)
+
+				if measures.UpdateTime > all.UpdateTime {
+					all.UpdateTime = measures.UpdateTime
+				}
+			}
+		}
+
+		if err != nil {
+			return all, resp, err
+		}
+	}
+
+	return all, resp, nil
+}
+
+// GetmeasSince returns measures created or modified since the given time (following pagination).
+//
+// It returns the new cursor (the update time of the response) along with the measures:
+// persist it and use it as since in the next call to fetch new values incrementally.
+// If since is zero, every measure is returned.
+//
+// If fetching a page fails, the measures fetched so far are returned along with the error,
+// but the cursor is not advanced: since is returned, so the next call fetches the missing measures as well.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
+func (s *MeasureService) GetmeasSince(ctx context.Context, measureTypes []MeasureType, category MeasureCategory, since time.Time) (*Measures, time.Time, error) {
+	measures, _, err := s.GetmeasAll(ctx, measureTypes, category, MeasureGetOptions{LastUpdate: since})
+	if err != nil {
+		return measures, since, err
+	}
+
+	if measures.UpdateTime == 0 {
+		return measures, since, nil
+	}
+
+	return measures, measures.UpdatedAt(), nil
+}
+
+// DefaultGetmeasRangeChunk is the size of the windows GetmeasRange splits date ranges into
+// when no chunk size is specified.
+const DefaultGetmeasRangeChunk = 30 * 24 * time.Hour
+
+// GetmeasRange returns measures taken between start and end.
+//
+// The Withings API limits how far a single date range query can reach,
+// so GetmeasRange splits the range into windows of chunk size (DefaultGetmeasRangeChunk if zero),
+// calls GetmeasAll for each window sequentially and merges the results.
+// Measure groups returned for multiple windows (at the boundaries) are only included once.
+//
+// The returned Response is the response of the last request.
+//
+// If fetching a window fails, the merged results fetched so far
+// (including the partial results of the failed window) are returned along with the error.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
+func (s *MeasureService) GetmeasRange(ctx context.Context, measureTypes []MeasureType, category MeasureCategory, start time.Time, end time.Time, chunk time.Duration) (*Measures, *Response, error) {
+	if end.Before(start) {
+		return nil, nil, errors.New("end must not be before start")
+	}
+
+	if chunk < 0 {
+		return nil, nil, errors.New("chunk must not be negative")
+	}
+
+	if chunk == 0 {
+		chunk = DefaultGetmeasRangeChunk
+	}
+
+	var (
+		all  *Measures
+		resp *Response
+	)
+
+	seen := map[int64]struct{}{}
+
+	for windowStart := start; ; windowStart = windowStart.Add(chunk) {
+		windowEnd := windowStart.Add(chunk)
+		if windowEnd.After(end) {
+			windowEnd = end
+		}
+
+		var (
+			measures *Measures
+			err      error
+		)
+
+		measures, resp, err = s.GetmeasAll(ctx, measureTypes, category, MeasureGetOptions{
+			StartDate: windowStart,
+			EndDate:   windowEnd,
+		})
+		if measures == nil {
+			return all, resp, err
+		}
+
+		groups := make([]MeasureGroup, 0, len(measures.MeasureGroups))
+
+		for _, group := range measures.MeasureGroups {
+			if _, ok := seen[group.GroupID]; ok {
+				continue
+			}
+
+			seen[group.GroupID] = struct{}{}
+
+			groups = append(groups, group)
+		}
+
+		if all == nil {
+			all = measures
+			all.MeasureGroups = groups
+		} else {
+			all.MeasureGroups = append(all.MeasureGroups, groups...)
+
+			if measures.UpdateTime > all.UpdateTime {
+				all.UpdateTime = measures.UpdateTime
+			}
+		}
+
+		if err != nil {
+			return all, resp, err
+		}
+
+		if !windowEnd.Before(end) {
+			return all, resp, nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return all, resp, err
+		}
+	}
+}
+
+// latestMeasureWindows are the periods Latest looks for measures in (in order).
+// A zero value means no date filter.
+var latestMeasureWindows = []time.Duration{
+	7 * 24 * time.Hour,
+	90 * 24 * time.Hour,
+	365 * 24 * time.Hour,
+	0,
+}
+
+// Latest returns the most recent real measure of a given type along with the time it was taken
+// (in the user's timezone, see MeasureGroup.Time).
+//
+// Latest looks for measures in gradually widening time windows, so recent measures are found quickly.
+// If no measure is found, Latest returns a nil Measure.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measure-getmeas
+func (s *MeasureService) Latest(ctx context.Context, measureType MeasureType) (*Measure, time.Time, error) {
+	if !measureType.IsValid() {
+		return nil, time.Time{}, errors.New("invalid measure type")
+	}
+
+	now := time.Now()
+
+	for _, window := range latestMeasureWindows {
+		var opts MeasureGetOptions
+
+		if window > 0 {
+			opts.StartDate = now.Add(-window)
+			opts.EndDate = now
+		}
+
+		measures, _, err := s.GetmeasAll(ctx, []MeasureType{measureType}, MeasureCategoryRealMeasure, opts)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		var (
+			latest      *Measure
+			latestGroup MeasureGroup
+		)
+
+		for _, group := range measures.MeasureGroups {
+			for i, measure := range group.Measures {
+				if measure.Type != measureType || (latest != nil && group.Date <= latestGroup.Date) {
+					continue
+				}
+
+				latest = &group.Measures[i]
+				latestGroup = group
+			}
+		}
+
+		if latest != nil {
+			return latest, latestGroup.Time(), nil
+		}
+	}
+
+	return nil, time.Time{}, nil
+}
+
 // ActivityField is a type of metric tracked during an activity.
 //
 // Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getactivity
@@ -331,6 +1097,38 @@ func AllActivityFields() []ActivityField {
 	}
 }
 
+// Brand is the source of activity data.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getactivity
+type Brand int
+
+// Brand values
+const (
+	BrandWithings   Brand = 1  // Data measured by a Withings device.
+	BrandThirdParty Brand = 18 // Data imported from a third party application or device.
+)
+
+var brandNames = map[Brand]string{
+	BrandWithings:   "Withings",
+	BrandThirdParty: "Third Party",
+}
+
+// IsValid checks if v is a valid Brand.
+func (v Brand) IsValid() bool {
+	_, ok := brandNames[v]
+
+	return ok
+}
+
+// String returns the human readable name of v.
+func (v Brand) String() string {
+	if name, ok := brandNames[v]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Brand(%d)", int(v))
+}
+
 type getactivityResponse struct {
 	Body Activities `json:"body"`
 }
@@ -340,26 +1138,30 @@ type getactivityResponse struct {
 // Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getactivity
 type Activities struct {
 	Activities []Activity `json:"activities"`
+
+	// RequestedFields is the list of (valid) fields requested from the API.
+	//
+	// Fields that were not requested are always zero in the returned activities.
+	RequestedFields []ActivityField `json:"-"`
 }
 
 // Activity aggregates metrics of a single activity.
 //
 // Fields are populated based on the requested fields.
-//
-// TODO: consider making fields pointers, so they dont get populated when no data is returned.
+// Use Has or Optional to tell a genuine zero value from missing data.
 //
 // Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getactivity
 type Activity struct {
 	Date      string `json:"date"`
 	Timezone  string `json:"timezone"`
 	DeviceID  string `json:"deviceid"`
-	Brand     int    `json:"brand"`
+	Brand     Brand  `json:"brand"`
 	IsTracker bool   `json:"is_tracker"`
 
 	// Fields
 	Steps         int     `json:"steps"`
 	Distance      float64 `json:"distance"`  // Note: spec says int, but it's in fact a float
-	Elevation     float64 `json:"elevation"` // Note: spec says int, but it's in fact a float
+	Elevation     float64 `json:"elevation"` // In floors. Note: spec says int, but it's in fact a float
 	Soft          int     `json:"soft"`
 	Moderate      int     `json:"moderate"`
 	Intense       int     `json:"intense"`
@@ -373,41 +1175,307 @@ type Activity struct {
 	HRZone1       int     `json:"hr_zone_1"`
 	HRZone2       int     `json:"hr_zone_2"`
 	HRZone3       int     `json:"hr_zone_3"`
+
+	// Present contains the keys (eg. ActivityField values)
+	// that were actually present in the response for this activity.
+	//
+	// It can be used to tell a genuine zero value from missing data.
+	Present map[string]bool `json:"-"`
+
+	// Synthetic is true if the activity was not returned by the API,
+	// but inserted by Activities.FillDays for a day without activity data.
+	Synthetic bool `json:"-"`
+}
+
+// Has reports whether field was present in the response for this activity.
+func (a Activity) Has(field ActivityField) bool {
+	return a.Present[string(field)]
 }
 
-// Getactivity provides daily aggregated activity data of a user.
-//
-// Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getactivity
-func (s *MeasureService) Getactivity(ctx context.Context, fields []ActivityField, opts MeasureGetOptions) (*Activities, *Response, error) {
-	fields = filterValidActivityFieldValues(fields)
+// FloorsClimbed returns the number of floors climbed during the day.
+//
+// Unlike in workouts, elevation is measured in floors for daily activities.
+func (a Activity) FloorsClimbed() float64 {
+	return a.Elevation
+}
+
+// ActivityOptional is a representation of Activity
+// where metrics missing from the response are nil instead of zero.
+type ActivityOptional struct {
+	Date      string
+	Timezone  string
+	DeviceID  string
+	Brand     Brand
+	IsTracker bool
+
+	// Fields
+	Steps         *int
+	Distance      *float64
+	Elevation     *float64
+	Soft          *int
+	Moderate      *int
+	Intense       *int
+	Active        *int
+	Calories      *float64
+	TotalCalories *float64
+	HRAverage     *int
+	HRMin         *int
+	HRMax         *int
+	HRZone0       *int
+	HRZone1       *int
+	HRZone2       *int
+	HRZone3       *int
+}
+
+// Optional converts the activity to ActivityOptional
+// based on the fields present in the response (see Present).
+func (a Activity) Optional() ActivityOptional {
+	intField := func(field ActivityField, v int) *int {
+		if !a.Has(field) {
+			return nil
+		}
+
+		return &v
+	}
+
+	floatField := func(field ActivityField, v float64) *float64 {
+		if !a.Has(field) {
+			return nil
+		}
+
+		return &v
+	}
+
+	return ActivityOptional{
+		Date:      a.Date,
+		Timezone:  a.Timezone,
+		DeviceID:  a.DeviceID,
+		Brand:     a.Brand,
+		IsTracker: a.IsTracker,
+
+		Steps:         intField(ActivityFieldSteps, a.Steps),
+		Distance:      floatField(ActivityFieldDistance, a.Distance),
+		Elevation:     floatField(ActivityFieldElevation, a.Elevation),
+		Soft:          intField(ActivityFieldSoft, a.Soft),
+		Moderate:      intField(ActivityFieldModerate, a.Moderate),
+		Intense:       intField(ActivityFieldIntense, a.Intense),
+		Active:        intField(ActivityFieldActive, a.Active),
+		Calories:      floatField(ActivityFieldCalories, a.Calories),
+		TotalCalories: floatField(ActivityFieldTotalCalories, a.TotalCalories),
+		HRAverage:     intField(ActivityFieldHRAverage, a.HRAverage),
+		HRMin:         intField(ActivityFieldHRMin, a.HRMin),
+		HRMax:         intField(ActivityFieldHRMax, a.HRMax),
+		HRZone0:       intField(ActivityFieldHRZone0, a.HRZone0),
+		HRZone1:       intField(ActivityFieldHRZone1, a.HRZone1),
+		HRZone2:       intField(ActivityFieldHRZone2, a.HRZone2),
+		HRZone3:       intField(ActivityFieldHRZone3, a.HRZone3),
+	}
+}
+
+// Day returns the day of the activity as the user's local midnight.
+//
+// The date is parsed in the timezone of the activity (or UTC if the timezone is empty).
+func (a Activity) Day() (time.Time, error) {
+	loc := time.UTC
+
+	if a.Timezone != "" {
+		var err error
+
+		loc, err = time.LoadLocation(a.Timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid activity timezone %q: %w", a.Timezone, err)
+		}
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", a.Date, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid activity date %q: %w", a.Date, err)
+	}
+
+	return day, nil
+}
+
+// FillDays returns the activities of every day between start and end (inclusive) in chronological order.
+//
+// Withings omits days without activity data, so FillDays inserts a zero-valued Activity
+// (with Synthetic set to true) for each missing day.
+// Days are determined in tz (UTC if nil). Activities outside of the range are not returned.
+func (a Activities) FillDays(start time.Time, end time.Time, tz *time.Location) []Activity {
+	if tz == nil {
+		tz = time.UTC
+	}
+
+	byDate := make(map[string][]Activity, len(a.Activities))
+	for _, activity := range a.Activities {
+		byDate[activity.Date] = append(byDate[activity.Date], activity)
+	}
+
+	start, end = start.In(tz), end.In(tz)
+	last := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, tz)
+
+	var activities []Activity
+
+	for i := 0; ; i++ {
+		day := time.Date(start.Year(), start.Month(), start.Day()+i, 0, 0, 0, 0, tz)
+		if day.After(last) {
+			break
+		}
+
+		date := day.Format("2006-01-02")
+
+		if existing, ok := byDate[date]; ok {
+			activities = append(activities, existing...)
+
+			continue
+		}
+
+		activities = append(activities, Activity{
+			Date:      date,
+			Timezone:  tz.String(),
+			Synthetic: true,
+		})
+	}
+
+	return activities
+}
+
+// Getactivity provides daily aggregated activity data of a user.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getactivity
+func (s *MeasureService) Getactivity(ctx context.Context, fields []ActivityField, opts ActivityGetOptions) (*Activities, *Response, error) {
+	if s == nil || s.client == nil {
+		return nil, nil, errClientNotInitialized
+	}
+
+	if err := s.client.requireScope(ctx, scopeUserActivity); err != nil {
+		return nil, nil, err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	fields = filterValidActivityFieldValues(fields)
+
+	if len(fields) == 0 {
+		return nil, nil, errors.New("need at least one activity field")
+	}
+
+	const urlPath = "v2/measure"
+
+	form := url.Values{
+		"action":      {"getactivity"},
+		"data_fields": {joinActivityFields(fields)},
+	}
+
+	opts.EncodeValues(form, DateModeYMD)
+
+	if opts.Offset > 0 {
+		form.Add("offset", fmt.Sprintf("%d", opts.Offset))
+	}
+
+	var rawResp map[string]interface{}
+
+	activityResp := new(getactivityResponse)
+
+	resp, err := s.client.PostForm(ctx, urlPath, form, &rawResp)
+	if err != nil {
+		return &activityResp.Body, resp, err
+	}
+
+	metadata := new(mapstructure.Metadata)
 
-	if len(fields) == 0 {
-		return nil, nil, errors.New("need at least one activity field")
+	err = decodeWithMetadata(rawResp, activityResp, metadata)
+	if err != nil {
+		return &activityResp.Body, resp, err
 	}
 
-	const urlPath = "v2/measure"
+	activityResp.Body.setPresentFields(metadata.Keys)
+	activityResp.Body.RequestedFields = fields
 
-	form := url.Values{
-		"action":      {"getactivity"},
-		"data_fields": {joinActivityFields(fields)},
+	if opts.DeviceID != "" {
+		activityResp.Body.filterDevice(opts.DeviceID)
 	}
 
-	if !opts.LastUpdate.IsZero() {
-		form.Add("lastupdate", fmt.Sprintf("%d", opts.LastUpdate.Unix()))
-	} else if !opts.StartDate.IsZero() && !opts.EndDate.IsZero() {
-		form.Add("startdateymd", opts.StartDate.Format("2006-01-02"))
-		form.Add("enddateymd", opts.EndDate.Format("2006-01-02"))
+	return &activityResp.Body, resp, nil
+}
+
+// filterDevice drops activities not recorded by the device.
+func (a *Activities) filterDevice(deviceID string) {
+	activities := a.Activities[:0]
+
+	for _, activity := range a.Activities {
+		if activity.DeviceID == deviceID {
+			activities = append(activities, activity)
+		}
 	}
 
-	if opts.Offset > 0 {
-		form.Add("offset", fmt.Sprintf("%d", opts.Offset))
+	a.Activities = activities
+}
+
+// GetactivityAll calls Getactivity repeatedly, following pagination until all pages are fetched,
+// and returns the merged results.
+//
+// The number of requests can be limited by setting MaxPages in opts.
+// If the limit is reached, the returned Response indicates that there is more data to fetch.
+//
+// If fetching a page fails after some pages were fetched successfully,
+// the merged results of those pages are returned along with a *PaginationError
+// containing the offset to resume from.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getactivity
+func (s *MeasureService) GetactivityAll(ctx context.Context, fields []ActivityField, opts ActivityGetOptions) (*Activities, *Response, error) {
+	var all *Activities
+
+	p := Paginator{Offset: opts.Offset, MaxPages: opts.MaxPages}
+
+	resp, err := p.Paginate(ctx, func(ctx context.Context, offset int) (*Response, error) {
+		opts.Offset = offset
+
+		activities, resp, err := s.Getactivity(ctx, fields, opts)
+		if err != nil {
+			return resp, err
+		}
+
+		if all == nil {
+			all = activities
+		} else {
+			all.Activities = append(all.Activities, activities.Activities...)
+		}
+
+		return resp, nil
+	})
+
+	return all, resp, err
+}
+
+// setPresentFields populates the Present field of each activity from a list of decoded keys.
+func (a *Activities) setPresentFields(keys []string) {
+	for i := range a.Activities {
+		a.Activities[i].Present = map[string]bool{}
 	}
 
-	activityResp := new(getactivityResponse)
+	const prefix = "body.activities["
+
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		rest := strings.TrimPrefix(key, prefix)
+
+		end := strings.Index(rest, "].")
+		if end < 0 {
+			continue
+		}
 
-	resp, err := s.client.PostForm(ctx, urlPath, form, activityResp)
+		i, err := strconv.Atoi(rest[:end])
+		if err != nil || i < 0 || i >= len(a.Activities) {
+			continue
+		}
 
-	return &activityResp.Body, resp, err
+		a.Activities[i].Present[rest[end+2:]] = true
+	}
 }
 
 func filterValidActivityFieldValues(values []ActivityField) []ActivityField {
@@ -505,9 +1573,9 @@ type IntradayActivities struct {
 //
 // Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getintradayactivity
 type IntradayActivity struct {
-	DeviceID string `json:"deviceid"`
-	Model    string `json:"model"`
-	ModelID  int    `json:"model_id"`
+	DeviceID string      `json:"deviceid"`
+	Model    string      `json:"model"`
+	ModelID  DeviceModel `json:"model_id"`
 
 	// Fields
 	Steps     int     `json:"steps"`
@@ -521,16 +1589,49 @@ type IntradayActivity struct {
 	SpO2      int     `json:"spo2_auto"`
 }
 
+// FloorsClimbed returns the number of floors climbed during the interval.
+func (a IntradayActivity) FloorsClimbed() float64 {
+	return a.Elevation
+}
+
 // Getintradayactivity provides activity data for the user with a fine granularity.
 //
+// The date range must not exceed MaxIntradayActivityRange.
+// Use GetintradayactivityRange or GetintradayactivityStream for longer periods.
+// The endpoint does not support filtering by LastUpdate: an error is returned if it is set in opts.
+//
 // Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getintradayactivity
 func (s *MeasureService) Getintradayactivity(ctx context.Context, fields []IntradayActivityField, opts MeasureGetOptions) (*IntradayActivities, *Response, error) {
+	if s == nil || s.client == nil {
+		return nil, nil, errClientNotInitialized
+	}
+
+	if err := s.client.requireScope(ctx, scopeUserActivity); err != nil {
+		return nil, nil, err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	fields = filterValidIntradayActivityFieldValues(fields)
 
 	if len(fields) == 0 {
 		return nil, nil, errors.New("need at least one intraday activity data field")
 	}
 
+	if !opts.LastUpdate.IsZero() {
+		return nil, nil, errors.New("LastUpdate is not supported by the intraday activity endpoint")
+	}
+
+	if opts.EndDate.Before(opts.StartDate) {
+		return nil, nil, errors.New("end must not be before start")
+	}
+
+	if opts.EndDate.Sub(opts.StartDate) > MaxIntradayActivityRange {
+		return nil, nil, fmt.Errorf("date range exceeds %s: use GetintradayactivityRange to fetch longer periods", MaxIntradayActivityRange)
+	}
+
 	const urlPath = "v2/measure"
 
 	form := url.Values{
@@ -538,9 +1639,10 @@ func (s *MeasureService) Getintradayactivity(ctx context.Context, fields []Intra
 		"data_fields": {joinIntradayActivityFields(fields)},
 	}
 
-	if !opts.StartDate.IsZero() && !opts.EndDate.IsZero() {
-		form.Add("startdate", fmt.Sprintf("%d", opts.StartDate.Unix()))
-		form.Add("enddate", fmt.Sprintf("%d", opts.EndDate.Unix()))
+	DateRange{Start: opts.StartDate, End: opts.EndDate}.EncodeValues(form, DateModeUnix)
+
+	if opts.Offset > 0 {
+		form.Add("offset", fmt.Sprintf("%d", opts.Offset))
 	}
 
 	intradayactivityResp := new(getintradayactivityResponse)
@@ -550,6 +1652,50 @@ func (s *MeasureService) Getintradayactivity(ctx context.Context, fields []Intra
 	return &intradayactivityResp.Body, resp, err
 }
 
+// GetintradayactivityAll calls Getintradayactivity repeatedly, following pagination until all pages are fetched,
+// and returns the merged results.
+//
+// Series of later pages override earlier ones on key collision.
+//
+// The number of requests can be limited by setting MaxPages in opts.
+// If the limit is reached, the returned Response indicates that there is more data to fetch.
+//
+// If fetching a page fails after some pages were fetched successfully,
+// the merged results of those pages are returned along with a *PaginationError
+// containing the offset to resume from.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getintradayactivity
+func (s *MeasureService) GetintradayactivityAll(ctx context.Context, fields []IntradayActivityField, opts MeasureGetOptions) (*IntradayActivities, *Response, error) {
+	var all *IntradayActivities
+
+	p := Paginator{Offset: opts.Offset, MaxPages: opts.MaxPages}
+
+	resp, err := p.Paginate(ctx, func(ctx context.Context, offset int) (*Response, error) {
+		opts.Offset = offset
+
+		activities, resp, err := s.Getintradayactivity(ctx, fields, opts)
+		if err != nil {
+			return resp, err
+		}
+
+		if all == nil {
+			all = activities
+		} else {
+			if all.Series == nil {
+				all.Series = make(map[string]IntradayActivity, len(activities.Series))
+			}
+
+			for key, activity := range activities.Series {
+				all.Series[key] = activity
+			}
+		}
+
+		return resp, nil
+	})
+
+	return all, resp, err
+}
+
 func filterValidIntradayActivityFieldValues(values []IntradayActivityField) []IntradayActivityField {
 	var validValues []IntradayActivityField
 
@@ -574,6 +1720,111 @@ func joinIntradayActivityFields(fields []IntradayActivityField) string {
 	return strings.Join(s, ",")
 }
 
+// MaxIntradayActivityRange is the longest time range the Withings API returns
+// intraday activity data for in a single request.
+//
+// Longer ranges are silently truncated by the API,
+// so Getintradayactivity rejects them with an error.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getintradayactivity
+const MaxIntradayActivityRange = 24 * time.Hour
+
+// IntradayActivitySample is an IntradayActivity paired with its timestamp.
+type IntradayActivitySample struct {
+	Time time.Time
+
+	IntradayActivity
+}
+
+// ParseIntradayTimestamp parses a key of IntradayActivities.Series (a unix timestamp in seconds).
+func ParseIntradayTimestamp(key string) (time.Time, error) {
+	timestamp, err := strconv.ParseInt(key, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid intraday activity timestamp %q: %w", key, err)
+	}
+
+	return time.Unix(timestamp, 0), nil
+}
+
+// Sorted returns the series as a list of samples sorted by time.
+//
+// The keys of the series (unix timestamps) are parsed once, so the samples are easy to iterate over (eg. for plotting).
+func (a IntradayActivities) Sorted() ([]IntradayActivitySample, error) {
+	samples := make([]IntradayActivitySample, 0, len(a.Series))
+
+	for key, activity := range a.Series {
+		t, err := ParseIntradayTimestamp(key)
+		if err != nil {
+			return nil, err
+		}
+
+		samples = append(samples, IntradayActivitySample{
+			Time:             t,
+			IntradayActivity: activity,
+		})
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Time.Before(samples[j].Time)
+	})
+
+	return samples, nil
+}
+
+// GetintradayactivityStream fetches intraday activity data between start and end
+// and calls fn for each sample in chronological order.
+//
+// The time range is split into chunks of MaxIntradayActivityRange,
+// so only one chunk is held in memory at a time.
+// If fn returns an error, streaming stops and the error is returned.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getintradayactivity
+func (s *MeasureService) GetintradayactivityStream(ctx context.Context, fields []IntradayActivityField, start time.Time, end time.Time, fn func(IntradayActivitySample) error) error {
+	if s == nil || s.client == nil {
+		return errClientNotInitialized
+	}
+
+	if !start.Before(end) {
+		return errors.New("start must be before end")
+	}
+
+	for chunkStart := start; chunkStart.Before(end); {
+		chunkEnd := chunkStart.Add(MaxIntradayActivityRange)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		activities, _, err := s.GetintradayactivityAll(ctx, fields, MeasureGetOptions{StartDate: chunkStart, EndDate: chunkEnd})
+		if err != nil {
+			return err
+		}
+
+		samples, err := activities.Sorted()
+		if err != nil {
+			return err
+		}
+
+		for _, sample := range samples {
+			// Chunk boundaries are inclusive: make sure samples are only emitted once.
+			if sample.Time.Before(chunkStart) || (chunkEnd.Before(end) && !sample.Time.Before(chunkEnd)) {
+				continue
+			}
+
+			if err := fn(sample); err != nil {
+				return err
+			}
+		}
+
+		chunkStart = chunkEnd
+	}
+
+	return nil
+}
+
 // WorkoutField is a type of metric tracked during workout sessions.
 //
 // Withings API docs: https://developer.withings.com/api-reference/#operation/measurev2-getworkouts
@@ -597,7 +1848,7 @@ const (
 	WorkoutFieldSpO2Average       WorkoutField = "spo2_average"        // Average percent of SpO2 percent value during a workout.
 	WorkoutFieldSteps             WorkoutField = "steps"               // Number of steps.
 	WorkoutFieldDistance          WorkoutField = "distance"            // Distance travelled (in meters).
-	WorkoutFieldElevation         WorkoutField = "elevation"           // Number of floors climbed.
+	WorkoutFieldElevation         WorkoutField = "elevation"           // Elevation climbed (in meters).
 	WorkoutFieldPoolLaps          WorkoutField = "pool_laps"           // Number of pool laps.
 	WorkoutFieldStrokes           WorkoutField = "strokes"             // Number of strokes.
 	WorkoutFieldPoolLength        WorkoutField = "pool_length"         // Length of the pool.
@@ -659,6 +1910,293 @@ func AllWorkoutFields() []WorkoutField {
 	}
 }
 
+// WorkoutCategory is the type of a workout session.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/measurev2-getworkouts
+type WorkoutCategory int
+
+// WorkoutCategory values
+const (
+	WorkoutCategoryWalk          WorkoutCategory = 1   // Walk
+	WorkoutCategoryRun           WorkoutCategory = 2   // Run
+	WorkoutCategoryHiking        WorkoutCategory = 3   // Hiking
+	WorkoutCategorySkating       WorkoutCategory = 4   // Skating
+	WorkoutCategoryBMX           WorkoutCategory = 5   // BMX
+	WorkoutCategoryBicycling     WorkoutCategory = 6   // Bicycling
+	WorkoutCategorySwimming      WorkoutCategory = 7   // Swimming
+	WorkoutCategorySurfing       WorkoutCategory = 8   // Surfing
+	WorkoutCategoryKitesurfing   WorkoutCategory = 9   // Kitesurfing
+	WorkoutCategoryWindsurfing   WorkoutCategory = 10  // Windsurfing
+	WorkoutCategoryBodyboard     WorkoutCategory = 11  // Bodyboard
+	WorkoutCategoryTennis        WorkoutCategory = 12  // Tennis
+	WorkoutCategoryTableTennis   WorkoutCategory = 13  // Table Tennis
+	WorkoutCategorySquash        WorkoutCategory = 14  // Squash
+	WorkoutCategoryBadminton     WorkoutCategory = 15  // Badminton
+	WorkoutCategoryLiftWeights   WorkoutCategory = 16  // Lift Weights
+	WorkoutCategoryCalisthenics  WorkoutCategory = 17  // Calisthenics
+	WorkoutCategoryElliptical    WorkoutCategory = 18  // Elliptical
+	WorkoutCategoryPilates       WorkoutCategory = 19  // Pilates
+	WorkoutCategoryBasketball    WorkoutCategory = 20  // Basketball
+	WorkoutCategorySoccer        WorkoutCategory = 21  // Soccer
+	WorkoutCategoryFootball      WorkoutCategory = 22  // Football
+	WorkoutCategoryRugby         WorkoutCategory = 23  // Rugby
+	WorkoutCategoryVolleyball    WorkoutCategory = 24  // Volleyball
+	WorkoutCategoryWaterpolo     WorkoutCategory = 25  // Waterpolo
+	WorkoutCategoryHorseRiding   WorkoutCategory = 26  // Horse Riding
+	WorkoutCategoryGolf          WorkoutCategory = 27  // Golf
+	WorkoutCategoryYoga          WorkoutCategory = 28  // Yoga
+	WorkoutCategoryDancing       WorkoutCategory = 29  // Dancing
+	WorkoutCategoryBoxing        WorkoutCategory = 30  // Boxing
+	WorkoutCategoryFencing       WorkoutCategory = 31  // Fencing
+	WorkoutCategoryWrestling     WorkoutCategory = 32  // Wrestling
+	WorkoutCategoryMartialArts   WorkoutCategory = 33  // Martial Arts
+	WorkoutCategorySkiing        WorkoutCategory = 34  // Skiing
+	WorkoutCategorySnowboarding  WorkoutCategory = 35  // Snowboarding
+	WorkoutCategoryOther         WorkoutCategory = 36  // Other
+	WorkoutCategoryNoActivity    WorkoutCategory = 128 // No Activity
+	WorkoutCategoryRowing        WorkoutCategory = 187 // Rowing
+	WorkoutCategoryZumba         WorkoutCategory = 188 // Zumba
+	WorkoutCategoryBaseball      WorkoutCategory = 191 // Baseball
+	WorkoutCategoryHandball      WorkoutCategory = 192 // Handball
+	WorkoutCategoryHockey        WorkoutCategory = 193 // Hockey
+	WorkoutCategoryIceHockey     WorkoutCategory = 194 // Ice Hockey
+	WorkoutCategoryClimbing      WorkoutCategory = 195 // Climbing
+	WorkoutCategoryIceSkating    WorkoutCategory = 196 // Ice Skating
+	WorkoutCategoryMultiSport    WorkoutCategory = 272 // Multi-Sport
+	WorkoutCategoryIndoorWalk    WorkoutCategory = 306 // Indoor Walk
+	WorkoutCategoryIndoorRunning WorkoutCategory = 307 // Indoor Running
+	WorkoutCategoryIndoorCycling WorkoutCategory = 308 // Indoor Cycling
+)
+
+var validWorkoutCategoryValues = map[WorkoutCategory]struct{}{
+	WorkoutCategoryWalk:          {},
+	WorkoutCategoryRun:           {},
+	WorkoutCategoryHiking:        {},
+	WorkoutCategorySkating:       {},
+	WorkoutCategoryBMX:           {},
+	WorkoutCategoryBicycling:     {},
+	WorkoutCategorySwimming:      {},
+	WorkoutCategorySurfing:       {},
+	WorkoutCategoryKitesurfing:   {},
+	WorkoutCategoryWindsurfing:   {},
+	WorkoutCategoryBodyboard:     {},
+	WorkoutCategoryTennis:        {},
+	WorkoutCategoryTableTennis:   {},
+	WorkoutCategorySquash:        {},
+	WorkoutCategoryBadminton:     {},
+	WorkoutCategoryLiftWeights:   {},
+	WorkoutCategoryCalisthenics:  {},
+	WorkoutCategoryElliptical:    {},
+	WorkoutCategoryPilates:       {},
+	WorkoutCategoryBasketball:    {},
+	WorkoutCategorySoccer:        {},
+	WorkoutCategoryFootball:      {},
+	WorkoutCategoryRugby:         {},
+	WorkoutCategoryVolleyball:    {},
+	WorkoutCategoryWaterpolo:     {},
+	WorkoutCategoryHorseRiding:   {},
+	WorkoutCategoryGolf:          {},
+	WorkoutCategoryYoga:          {},
+	WorkoutCategoryDancing:       {},
+	WorkoutCategoryBoxing:        {},
+	WorkoutCategoryFencing:       {},
+	WorkoutCategoryWrestling:     {},
+	WorkoutCategoryMartialArts:   {},
+	WorkoutCategorySkiing:        {},
+	WorkoutCategorySnowboarding:  {},
+	WorkoutCategoryOther:         {},
+	WorkoutCategoryNoActivity:    {},
+	WorkoutCategoryRowing:        {},
+	WorkoutCategoryZumba:         {},
+	WorkoutCategoryBaseball:      {},
+	WorkoutCategoryHandball:      {},
+	WorkoutCategoryHockey:        {},
+	WorkoutCategoryIceHockey:     {},
+	WorkoutCategoryClimbing:      {},
+	WorkoutCategoryIceSkating:    {},
+	WorkoutCategoryMultiSport:    {},
+	WorkoutCategoryIndoorWalk:    {},
+	WorkoutCategoryIndoorRunning: {},
+	WorkoutCategoryIndoorCycling: {},
+}
+
+var workoutCategoryNames = map[WorkoutCategory]string{
+	WorkoutCategoryWalk:          "Walk",
+	WorkoutCategoryRun:           "Run",
+	WorkoutCategoryHiking:        "Hiking",
+	WorkoutCategorySkating:       "Skating",
+	WorkoutCategoryBMX:           "BMX",
+	WorkoutCategoryBicycling:     "Bicycling",
+	WorkoutCategorySwimming:      "Swimming",
+	WorkoutCategorySurfing:       "Surfing",
+	WorkoutCategoryKitesurfing:   "Kitesurfing",
+	WorkoutCategoryWindsurfing:   "Windsurfing",
+	WorkoutCategoryBodyboard:     "Bodyboard",
+	WorkoutCategoryTennis:        "Tennis",
+	WorkoutCategoryTableTennis:   "Table Tennis",
+	WorkoutCategorySquash:        "Squash",
+	WorkoutCategoryBadminton:     "Badminton",
+	WorkoutCategoryLiftWeights:   "Lift Weights",
+	WorkoutCategoryCalisthenics:  "Calisthenics",
+	WorkoutCategoryElliptical:    "Elliptical",
+	WorkoutCategoryPilates:       "Pilates",
+	WorkoutCategoryBasketball:    "Basketball",
+	WorkoutCategorySoccer:        "Soccer",
+	WorkoutCategoryFootball:      "Football",
+	WorkoutCategoryRugby:         "Rugby",
+	WorkoutCategoryVolleyball:    "Volleyball",
+	WorkoutCategoryWaterpolo:     "Waterpolo",
+	WorkoutCategoryHorseRiding:   "Horse Riding",
+	WorkoutCategoryGolf:          "Golf",
+	WorkoutCategoryYoga:          "Yoga",
+	WorkoutCategoryDancing:       "Dancing",
+	WorkoutCategoryBoxing:        "Boxing",
+	WorkoutCategoryFencing:       "Fencing",
+	WorkoutCategoryWrestling:     "Wrestling",
+	WorkoutCategoryMartialArts:   "Martial Arts",
+	WorkoutCategorySkiing:        "Skiing",
+	WorkoutCategorySnowboarding:  "Snowboarding",
+	WorkoutCategoryOther:         "Other",
+	WorkoutCategoryNoActivity:    "No Activity",
+	WorkoutCategoryRowing:        "Rowing",
+	WorkoutCategoryZumba:         "Zumba",
+	WorkoutCategoryBaseball:      "Baseball",
+	WorkoutCategoryHandball:      "Handball",
+	WorkoutCategoryHockey:        "Hockey",
+	WorkoutCategoryIceHockey:     "Ice Hockey",
+	WorkoutCategoryClimbing:      "Climbing",
+	WorkoutCategoryIceSkating:    "Ice Skating",
+	WorkoutCategoryMultiSport:    "Multi-Sport",
+	WorkoutCategoryIndoorWalk:    "Indoor Walk",
+	WorkoutCategoryIndoorRunning: "Indoor Running",
+	WorkoutCategoryIndoorCycling: "Indoor Cycling",
+}
+
+// IsValid checks if v is a valid WorkoutCategory.
+func (v WorkoutCategory) IsValid() bool {
+	_, ok := validWorkoutCategoryValues[v]
+
+	return ok
+}
+
+// String returns the human readable name of v.
+func (v WorkoutCategory) String() string {
+	if name, ok := workoutCategoryNames[v]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("WorkoutCategory(%d)", int(v))
+}
+
+// WorkoutCategorySymbol is a WorkoutCategory that is JSON encoded as its symbolic name (eg. "Run")
+// instead of its numeric value.
+//
+// It can be used to store workout categories in a stable, human readable form.
+// Decoding accepts both the symbolic name and the numeric value.
+type WorkoutCategorySymbol WorkoutCategory
+
+// MarshalJSON encodes v as its symbolic name.
+func (v WorkoutCategorySymbol) MarshalJSON() ([]byte, error) {
+	if !WorkoutCategory(v).IsValid() {
+		return nil, fmt.Errorf("invalid WorkoutCategory: %d", int(v))
+	}
+
+	return json.Marshal(WorkoutCategory(v).String())
+}
+
+// UnmarshalJSON decodes a symbolic name or a numeric value and rejects unknown values.
+func (v *WorkoutCategorySymbol) UnmarshalJSON(data []byte) error {
+	var name string
+
+	if err := json.Unmarshal(data, &name); err == nil {
+		for category, n := range workoutCategoryNames {
+			if n == name {
+				*v = WorkoutCategorySymbol(category)
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("unknown WorkoutCategory name: %q", name)
+	}
+
+	var i int
+
+	if err := json.Unmarshal(data, &i); err != nil {
+		return err
+	}
+
+	if !WorkoutCategory(i).IsValid() {
+		return fmt.Errorf("invalid WorkoutCategory: %d", i)
+	}
+
+	*v = WorkoutCategorySymbol(i)
+
+	return nil
+}
+
+// WorkoutCategoryName returns the human readable name of a workout category code.
+//
+// It can be used to resolve names of categories stored as raw numbers.
+func WorkoutCategoryName(category int) string {
+	return WorkoutCategory(category).String()
+}
+
+// AllWorkoutCategories returns the list of all WorkoutCategory values.
+func AllWorkoutCategories() []WorkoutCategory {
+	return []WorkoutCategory{
+		WorkoutCategoryWalk,
+		WorkoutCategoryRun,
+		WorkoutCategoryHiking,
+		WorkoutCategorySkating,
+		WorkoutCategoryBMX,
+		WorkoutCategoryBicycling,
+		WorkoutCategorySwimming,
+		WorkoutCategorySurfing,
+		WorkoutCategoryKitesurfing,
+		WorkoutCategoryWindsurfing,
+		WorkoutCategoryBodyboard,
+		WorkoutCategoryTennis,
+		WorkoutCategoryTableTennis,
+		WorkoutCategorySquash,
+		WorkoutCategoryBadminton,
+		WorkoutCategoryLiftWeights,
+		WorkoutCategoryCalisthenics,
+		WorkoutCategoryElliptical,
+		WorkoutCategoryPilates,
+		WorkoutCategoryBasketball,
+		WorkoutCategorySoccer,
+		WorkoutCategoryFootball,
+		WorkoutCategoryRugby,
+		WorkoutCategoryVolleyball,
+		WorkoutCategoryWaterpolo,
+		WorkoutCategoryHorseRiding,
+		WorkoutCategoryGolf,
+		WorkoutCategoryYoga,
+		WorkoutCategoryDancing,
+		WorkoutCategoryBoxing,
+		WorkoutCategoryFencing,
+		WorkoutCategoryWrestling,
+		WorkoutCategoryMartialArts,
+		WorkoutCategorySkiing,
+		WorkoutCategorySnowboarding,
+		WorkoutCategoryOther,
+		WorkoutCategoryNoActivity,
+		WorkoutCategoryRowing,
+		WorkoutCategoryZumba,
+		WorkoutCategoryBaseball,
+		WorkoutCategoryHandball,
+		WorkoutCategoryHockey,
+		WorkoutCategoryIceHockey,
+		WorkoutCategoryClimbing,
+		WorkoutCategoryIceSkating,
+		WorkoutCategoryMultiSport,
+		WorkoutCategoryIndoorWalk,
+		WorkoutCategoryIndoorRunning,
+		WorkoutCategoryIndoorCycling,
+	}
+}
+
 type getworkoutsResponse struct {
 	Body Workouts `json:"body"`
 }
@@ -678,19 +2216,80 @@ type Workouts struct {
 //
 // Withings API docs: https://developer.withings.com/api-reference/#operation/measurev2-getworkouts
 type Workout struct {
-	Category  int    `json:"category"`
-	Timezone  string `json:"timezone"`
-	Model     int    `json:"model"`
-	Attrib    int    `json:"attrib"`
-	Startdate int64  `json:"startdate"`
-	Enddate   int64  `json:"enddate"`
-	Date      string `json:"date"`
-	Modified  int64  `json:"modified"`
-	DeviceID  string `json:"deviceid"`
+	Category  WorkoutCategory `json:"category"`
+	Timezone  string          `json:"timezone"`
+	Model     DeviceModel     `json:"model"`
+	Attrib    int             `json:"attrib"`
+	Startdate int64           `json:"startdate"`
+	Enddate   int64           `json:"enddate"`
+	Date      string          `json:"date"`
+	Modified  int64           `json:"modified"`
+	DeviceID  string          `json:"deviceid"`
 
 	Data WorkoutData `json:"data"`
 }
 
+// CategoryName returns the human readable name of the workout category.
+func (w Workout) CategoryName() string {
+	return w.Category.String()
+}
+
+// StartTime returns the start of the workout in the workout's timezone.
+//
+// UTC is used if the timezone cannot be loaded.
+func (w Workout) StartTime() time.Time {
+	return time.Unix(w.Startdate, 0).In(loadLocationOrUTC(w.Timezone))
+}
+
+// EndTime returns the end of the workout in the workout's timezone.
+//
+// UTC is used if the timezone cannot be loaded.
+func (w Workout) EndTime() time.Time {
+	return time.Unix(w.Enddate, 0).In(loadLocationOrUTC(w.Timezone))
+}
+
+// Duration returns the wall-clock duration of the workout.
+func (w Workout) Duration() time.Duration {
+	return time.Duration(w.Enddate-w.Startdate) * time.Second
+}
+
+// ActiveDuration returns the duration of the workout excluding pauses.
+//
+// The pause detected by the algorithm (eg. during swimming) is preferred when present,
+// otherwise the pause recorded by the user is subtracted.
+// The result is never negative.
+func (w Workout) ActiveDuration() time.Duration {
+	pause := w.Data.AlgoPauseDuration
+	if pause == 0 {
+		pause = w.Data.PauseDuration
+	}
+
+	active := w.Duration() - time.Duration(pause)*time.Second
+	if active < 0 {
+		return 0
+	}
+
+	return active
+}
+
+// loadLocationOrUTC returns the location with the given name or UTC if it cannot be loaded.
+func loadLocationOrUTC(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// WorkoutData contains the metrics of a workout session.
+//
+// Note: Elevation is measured in meters, unlike in (intraday) activities,
+// where it is the number of floors climbed.
 type WorkoutData struct {
 	Calories          float64 `json:"calories"` // Note: spec says int, but it's in fact a float
 	Intensity         int     `json:"intensity"`
@@ -708,16 +2307,55 @@ type WorkoutData struct {
 	SpO2Average       int     `json:"spo2_average"`
 	Steps             int     `json:"steps"`
 	Distance          float64 `json:"distance"`  // Note: spec says int, but it's in fact a float
-	Elevation         float64 `json:"elevation"` // Note: spec says int, but it's in fact a float
+	Elevation         float64 `json:"elevation"` // In meters. Note: spec says int, but it's in fact a float
 	PoolLaps          int     `json:"pool_laps"`
 	Strokes           int     `json:"strokes"`
 	PoolLength        int     `json:"pool_length"`
 }
 
+// ElevationMeters returns the elevation climbed during the workout in meters.
+func (d WorkoutData) ElevationMeters() float64 {
+	return d.Elevation
+}
+
+// WorkoutFilterOptions contains workout specific filters for Getworkouts.
+//
+// The Withings API does not support these filters,
+// so they are applied after fetching the data:
+// pagination (More and Offset) still reflects the unfiltered result set.
+type WorkoutFilterOptions struct {
+	// Categories limits the results to workouts of the listed categories.
+	Categories []WorkoutCategory
+}
+
+// WorkoutFilterOption configures WorkoutFilterOptions.
+type WorkoutFilterOption func(o *WorkoutFilterOptions)
+
+// WithCategories limits workouts to the listed categories.
+func WithCategories(categories ...WorkoutCategory) WorkoutFilterOption {
+	return func(o *WorkoutFilterOptions) {
+		o.Categories = append(o.Categories, categories...)
+	}
+}
+
 // Getworkouts provides data relevant to workout sessions from the different trackers.
 //
+// Filters (eg. WithCategories) can be used to limit the returned workouts.
+//
 // Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getworkouts
-func (s *MeasureService) Getworkouts(ctx context.Context, fields []WorkoutField, opts MeasureGetOptions) (*Workouts, *Response, error) {
+func (s *MeasureService) Getworkouts(ctx context.Context, fields []WorkoutField, opts ActivityGetOptions, filters ...WorkoutFilterOption) (*Workouts, *Response, error) {
+	if s == nil || s.client == nil {
+		return nil, nil, errClientNotInitialized
+	}
+
+	if err := s.client.requireScope(ctx, scopeUserActivity); err != nil {
+		return nil, nil, err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	fields = filterValidWorkoutFieldValues(fields)
 
 	if len(fields) == 0 {
@@ -731,12 +2369,7 @@ func (s *MeasureService) Getworkouts(ctx context.Context, fields []WorkoutField,
 		"data_fields": {joinWorkoutFields(fields)},
 	}
 
-	if !opts.LastUpdate.IsZero() {
-		form.Add("lastupdate", fmt.Sprintf("%d", opts.LastUpdate.Unix()))
-	} else if !opts.StartDate.IsZero() && !opts.EndDate.IsZero() {
-		form.Add("startdateymd", opts.StartDate.Format("2006-01-02"))
-		form.Add("enddateymd", opts.EndDate.Format("2006-01-02"))
-	}
+	opts.EncodeValues(form, DateModeYMD)
 
 	if opts.Offset > 0 {
 		form.Add("offset", fmt.Sprintf("%d", opts.Offset))
@@ -745,8 +2378,91 @@ func (s *MeasureService) Getworkouts(ctx context.Context, fields []WorkoutField,
 	getworkoutsResp := new(getworkoutsResponse)
 
 	resp, err := s.client.PostForm(ctx, urlPath, form, getworkoutsResp)
+	if err != nil {
+		return &getworkoutsResp.Body, resp, err
+	}
+
+	if opts.DeviceID != "" {
+		getworkoutsResp.Body.filterDevice(opts.DeviceID)
+	}
+
+	var filterOpts WorkoutFilterOptions
+
+	for _, filter := range filters {
+		filter(&filterOpts)
+	}
+
+	if len(filterOpts.Categories) > 0 {
+		getworkoutsResp.Body.filterCategories(filterOpts.Categories)
+	}
+
+	return &getworkoutsResp.Body, resp, nil
+}
+
+// filterCategories drops workouts not belonging to any of the categories.
+func (w *Workouts) filterCategories(categories []WorkoutCategory) {
+	series := w.Series[:0]
+
+	for _, workout := range w.Series {
+		for _, category := range categories {
+			if workout.Category == category {
+				series = append(series, workout)
+
+				break
+			}
+		}
+	}
+
+	w.Series = series
+}
+
+// filterDevice drops workouts not recorded by the device.
+func (w *Workouts) filterDevice(deviceID string) {
+	series := w.Series[:0]
+
+	for _, workout := range w.Series {
+		if workout.DeviceID == deviceID {
+			series = append(series, workout)
+		}
+	}
+
+	w.Series = series
+}
+
+// GetworkoutsAll calls Getworkouts repeatedly, following pagination until all pages are fetched,
+// and returns the merged results.
+//
+// The number of requests can be limited by setting MaxPages in opts.
+// If the limit is reached, the returned Response indicates that there is more data to fetch.
+//
+// If fetching a page fails after some pages were fetched successfully,
+// the merged results of those pages are returned along with a *PaginationError
+// containing the offset to resume from.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/measurev2-getworkouts
+func (s *MeasureService) GetworkoutsAll(ctx context.Context, fields []WorkoutField, opts ActivityGetOptions, filters ...WorkoutFilterOption) (*Workouts, *Response, error) {
+	var all *Workouts
+
+	p := Paginator{Offset: opts.Offset, MaxPages: opts.MaxPages}
 
-	return &getworkoutsResp.Body, resp, err
+	resp, err := p.Paginate(ctx, func(ctx context.Context, offset int) (*Response, error) {
+		opts.Offset = offset
+
+		workouts, resp, err := s.Getworkouts(ctx, fields, opts, filters...)
+		if err != nil {
+			return resp, err
+		}
+
+		if all == nil {
+			all = workouts
+		} else {
+			all.Series = append(all.Series, workouts.Series...)
+		}
+
+		return resp, nil
+	})
+
+	return all, resp, err
 }
 
 func filterValidWorkoutFieldValues(values []WorkoutField) []WorkoutField {
@@ -772,3 +2488,27 @@ func joinWorkoutFields(fields []WorkoutField) string {
 
 	return strings.Join(s, ",")
 }
+
+// GetintradayactivityRange fetches intraday activity data between start and end.
+//
+// Unlike Getintradayactivity, it is not limited to MaxIntradayActivityRange:
+// the time range is split into chunks and the results are merged.
+// Use GetintradayactivityStream to avoid holding the entire result in memory.
+//
+// Withings API docs: https://developer.withings.com/api-reference#operation/measurev2-getintradayactivity
+func (s *MeasureService) GetintradayactivityRange(ctx context.Context, fields []IntradayActivityField, start time.Time, end time.Time) (*IntradayActivities, error) {
+	activities := &IntradayActivities{
+		Series: map[string]IntradayActivity{},
+	}
+
+	err := s.GetintradayactivityStream(ctx, fields, start, end, func(sample IntradayActivitySample) error {
+		activities.Series[strconv.FormatInt(sample.Time.Unix(), 10)] = sample.IntradayActivity
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
@@ -1,6 +1,17 @@
 package withings
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
 
 func TestMeasureType(t *testing.T) {
 	t.Run("AllValid", func(t *testing.T) {
@@ -11,11 +22,107 @@ func TestMeasureType(t *testing.T) {
 		}
 	})
 
+	t.Run("CommonValid", func(t *testing.T) {
+		for _, v := range CommonMeasureTypes() {
+			if !v.IsValid() {
+				t.Errorf("%d is supposed to be a valid MeasureType", v)
+			}
+		}
+	})
+
 	t.Run("Invalid", func(t *testing.T) {
 		if MeasureType(0).IsValid() {
 			t.Error("non existent MeasureType should not be valid")
 		}
 	})
+
+	t.Run("AllNamed", func(t *testing.T) {
+		for _, v := range AllMeasureTypes() {
+			if info, ok := v.Info(); !ok || info.Name == "" || info.Description == "" {
+				t.Errorf("%d is supposed to have a name and a description", v)
+			}
+		}
+	})
+
+	t.Run("Infos", func(t *testing.T) {
+		infos := MeasureTypeInfos()
+
+		if got, want := len(infos), len(AllMeasureTypes()); got != want {
+			t.Errorf("got %d infos; want %d", got, want)
+		}
+
+		if got, want := infos[MeasureTypeWeight].Unit, MeasureTypeWeight.Unit(); got != want {
+			t.Errorf("unit = %q; want %q", got, want)
+		}
+
+		delete(infos, MeasureTypeWeight)
+
+		if !MeasureTypeWeight.IsValid() {
+			t.Error("modifying the returned infos should not affect the package")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var v MeasureType
+
+		if err := json.Unmarshal([]byte(`11`), &v); err != nil || v != MeasureTypeHeartPulse {
+			t.Errorf("unmarshal = %d, %v; want %d", v, err, MeasureTypeHeartPulse)
+		}
+
+		// Unknown values are tolerated
+		if err := json.Unmarshal([]byte(`1000`), &v); err != nil || v != MeasureType(1000) {
+			t.Errorf("unmarshal = %d, %v; want %d", v, err, 1000)
+		}
+	})
+
+	t.Run("Symbol", func(t *testing.T) {
+		data, err := json.Marshal(MeasureTypeSymbol(MeasureTypeHeartPulse))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(data), `"Heart Pulse"`; got != want {
+			t.Errorf("marshal = %s; want %s", got, want)
+		}
+
+		for _, data := range []string{`"Heart Pulse"`, `11`} {
+			var v MeasureTypeSymbol
+
+			if err := json.Unmarshal([]byte(data), &v); err != nil || MeasureType(v) != MeasureTypeHeartPulse {
+				t.Errorf("unmarshal %s = %d, %v; want %d", data, v, err, MeasureTypeHeartPulse)
+			}
+		}
+
+		var v MeasureTypeSymbol
+
+		if err := json.Unmarshal([]byte(`"Unknown"`), &v); err == nil {
+			t.Error("expected an error for an unknown MeasureType name")
+		}
+
+		if err := json.Unmarshal([]byte(`0`), &v); err == nil {
+			t.Error("expected an error for an unknown MeasureType")
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		if got, want := MeasureTypeHeartPulse.String(), "Heart Pulse"; got != want {
+			t.Errorf("String() = %q; want %q", got, want)
+		}
+
+		if got, want := MeasureType(0).String(), "MeasureType(0)"; got != want {
+			t.Errorf("String() = %q; want %q", got, want)
+		}
+	})
+
+	t.Run("Unit", func(t *testing.T) {
+		if got, want := MeasureTypeWeight.Unit(), "kg"; got != want {
+			t.Errorf("Unit() = %q; want %q", got, want)
+		}
+
+		if got, want := MeasureTypeFatRatio.Unit(), "%"; got != want {
+			t.Errorf("Unit() = %q; want %q", got, want)
+		}
+	})
 }
 
 func TestMeasureCategory(t *testing.T) {
@@ -81,3 +188,1258 @@ func TestWorkoutField(t *testing.T) {
 		}
 	})
 }
+
+func TestWorkoutCategory(t *testing.T) {
+	t.Run("AllValid", func(t *testing.T) {
+		for _, v := range AllWorkoutCategories() {
+			if !v.IsValid() {
+				t.Errorf("%d is supposed to be a valid WorkoutCategory", v)
+			}
+
+			if _, ok := workoutCategoryNames[v]; !ok {
+				t.Errorf("%d is supposed to have a name", v)
+			}
+		}
+
+		if got, want := len(AllWorkoutCategories()), len(validWorkoutCategoryValues); got != want {
+			t.Errorf("got %d categories; want %d", got, want)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if WorkoutCategory(0).IsValid() {
+			t.Error("non existent WorkoutCategory should not be valid")
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		if got, want := WorkoutCategoryIndoorRunning.String(), "Indoor Running"; got != want {
+			t.Errorf("String() = %q; want %q", got, want)
+		}
+
+		if got, want := WorkoutCategory(0).String(), "WorkoutCategory(0)"; got != want {
+			t.Errorf("String() = %q; want %q", got, want)
+		}
+	})
+
+	t.Run("Symbol", func(t *testing.T) {
+		data, err := json.Marshal(WorkoutCategorySymbol(WorkoutCategoryIndoorRunning))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := string(data), `"Indoor Running"`; got != want {
+			t.Errorf("marshal = %s; want %s", got, want)
+		}
+
+		for _, data := range []string{`"Indoor Running"`, `307`} {
+			var v WorkoutCategorySymbol
+
+			if err := json.Unmarshal([]byte(data), &v); err != nil || WorkoutCategory(v) != WorkoutCategoryIndoorRunning {
+				t.Errorf("unmarshal %s = %d, %v; want %d", data, v, err, WorkoutCategoryIndoorRunning)
+			}
+		}
+
+		var v WorkoutCategorySymbol
+
+		if err := json.Unmarshal([]byte(`0`), &v); err == nil {
+			t.Error("expected an error for an unknown WorkoutCategory")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		workout := Workout{Category: WorkoutCategory(1000)}
+
+		data, err := json.Marshal(workout)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var decoded Workout
+
+		// Unknown values are tolerated
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded.Category != workout.Category {
+			t.Errorf("round trip = %d, %v; want %d", decoded.Category, err, workout.Category)
+		}
+	})
+
+	t.Run("Name", func(t *testing.T) {
+		if got, want := WorkoutCategoryName(7), "Swimming"; got != want {
+			t.Errorf("WorkoutCategoryName() = %q; want %q", got, want)
+		}
+
+		if got, want := (Workout{Category: WorkoutCategoryRun}).CategoryName(), "Run"; got != want {
+			t.Errorf("CategoryName() = %q; want %q", got, want)
+		}
+	})
+}
+
+func TestMeasureAttrib(t *testing.T) {
+	if got, want := MeasureAttribManual.String(), "Manual"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+
+	if MeasureAttrib(3).IsValid() {
+		t.Error("non existent MeasureAttrib should not be valid")
+	}
+
+	if !(MeasureGroup{Attrib: MeasureAttribManualCreation}).IsManualEntry() {
+		t.Error("measure group entered during user creation should be a manual entry")
+	}
+
+	if (MeasureGroup{Attrib: MeasureAttribDevice}).IsManualEntry() {
+		t.Error("measure group captured by a device should not be a manual entry")
+	}
+}
+
+func TestBrand(t *testing.T) {
+	if got, want := BrandWithings.String(), "Withings"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+
+	if got, want := Brand(2).String(), "Brand(2)"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+}
+
+func TestDateRange_EncodeValues(t *testing.T) {
+	start := time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC)
+	end := time.Date(2022, time.January, 2, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Unix", func(t *testing.T) {
+		form := url.Values{}
+
+		DateRange{Start: start, End: end}.EncodeValues(form, DateModeUnix)
+
+		if got, want := form.Get("startdate"), "1641038400"; got != want {
+			t.Errorf("startdate = %q; want %q", got, want)
+		}
+
+		if got, want := form.Get("enddate"), "1641124800"; got != want {
+			t.Errorf("enddate = %q; want %q", got, want)
+		}
+	})
+
+	t.Run("YMD", func(t *testing.T) {
+		form := url.Values{}
+
+		DateRange{Start: start, End: end}.EncodeValues(form, DateModeYMD)
+
+		if got, want := form.Get("startdateymd"), "2022-01-01"; got != want {
+			t.Errorf("startdateymd = %q; want %q", got, want)
+		}
+
+		if got, want := form.Get("enddateymd"), "2022-01-02"; got != want {
+			t.Errorf("enddateymd = %q; want %q", got, want)
+		}
+	})
+
+	t.Run("LastUpdate", func(t *testing.T) {
+		form := url.Values{}
+
+		DateRange{Start: start, End: end, LastUpdate: start}.EncodeValues(form, DateModeYMD)
+
+		if got, want := form.Get("lastupdate"), "1641038400"; got != want {
+			t.Errorf("lastupdate = %q; want %q", got, want)
+		}
+
+		if form.Get("startdateymd") != "" || form.Get("enddateymd") != "" {
+			t.Error("date range should not be sent with lastupdate")
+		}
+	})
+}
+
+func TestMeasureService_ClientNotInitialized(t *testing.T) {
+	s := new(MeasureService)
+
+	_, _, err := s.Getmeas(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{})
+	if err != errClientNotInitialized { // nolint: errorlint
+		t.Errorf("Getmeas error = %v; want %v", err, errClientNotInitialized)
+	}
+}
+
+func TestMeasureService_GetintradayactivityStream(t *testing.T) {
+	client, mux := setup(t)
+
+	start := time.Unix(1000000, 0)
+	end := start.Add(36 * time.Hour)
+
+	var requests int
+
+	mux.HandleFunc("/v2/measure", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		switch r.FormValue("startdate") {
+		case "1000000":
+			fmt.Fprint(w, `{"status":0,"body":{"series":{"1086400":{"steps":3},"1000060":{"steps":2},"1000000":{"steps":1}}}}`)
+
+		case "1086400":
+			fmt.Fprint(w, `{"status":0,"body":{"series":{"1086400":{"steps":3},"1129600":{"steps":4}}}}`)
+
+		default:
+			t.Errorf("unexpected startdate: %s", r.FormValue("startdate"))
+		}
+	})
+
+	var steps []int
+
+	err := client.Measure.GetintradayactivityStream(context.Background(), AllIntradayActivityFields(), start, end, func(sample IntradayActivitySample) error {
+		steps = append(steps, sample.Steps)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d; want 2", requests)
+	}
+
+	if want := []int{1, 2, 3, 4}; !reflect.DeepEqual(steps, want) {
+		t.Errorf("steps = %v; want %v", steps, want)
+	}
+}
+
+func TestMeasureService_Getactivity_Present(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v2/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{"activities":[{"date":"2022-01-01","steps":0},{"date":"2022-01-02","distance":12.5}]}}`)
+	})
+
+	activities, _, err := client.Measure.Getactivity(context.Background(), AllActivityFields(), ActivityGetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(activities.Activities) != 2 {
+		t.Fatalf("got %d activities; want 2", len(activities.Activities))
+	}
+
+	if !activities.Activities[0].Has(ActivityFieldSteps) {
+		t.Error("steps should be present in the first activity")
+	}
+
+	if activities.Activities[0].Has(ActivityFieldDistance) {
+		t.Error("distance should not be present in the first activity")
+	}
+
+	if activities.Activities[1].Has(ActivityFieldSteps) {
+		t.Error("steps should not be present in the second activity")
+	}
+
+	if !activities.Activities[1].Has(ActivityFieldDistance) {
+		t.Error("distance should be present in the second activity")
+	}
+
+	if got, want := len(activities.RequestedFields), len(AllActivityFields()); got != want {
+		t.Errorf("got %d requested fields; want %d", got, want)
+	}
+
+	t.Run("Optional", func(t *testing.T) {
+		optional := activities.Activities[0].Optional()
+
+		if optional.Steps == nil || *optional.Steps != 0 {
+			t.Errorf("steps = %v; want 0", optional.Steps)
+		}
+
+		if optional.Distance != nil {
+			t.Errorf("distance = %v; want nil", *optional.Distance)
+		}
+	})
+}
+
+func TestMeasureService_GetintradayactivityAll(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v2/measure", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("offset") {
+		case "":
+			fmt.Fprint(w, `{"status":0,"body":{"more":true,"offset":1,"series":{"1641038400":{"steps":1},"1641038460":{"steps":2}}}}`)
+
+		case "1":
+			fmt.Fprint(w, `{"status":0,"body":{"more":false,"series":{"1641038460":{"steps":3},"1641038520":{"steps":4}}}}`)
+
+		default:
+			t.Errorf("unexpected offset: %s", r.FormValue("offset"))
+		}
+	})
+
+	start := time.Unix(1641038400, 0)
+
+	activities, _, err := client.Measure.GetintradayactivityAll(context.Background(), AllIntradayActivityFields(), MeasureGetOptions{StartDate: start, EndDate: start.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(activities.Series), 3; got != want {
+		t.Fatalf("got %d samples; want %d", got, want)
+	}
+
+	if got, want := activities.Series["1641038460"].Steps, 3; got != want {
+		t.Errorf("steps = %d; want %d (later pages should override earlier ones)", got, want)
+	}
+}
+
+func TestMeasureService_Getintradayactivity_RangeLimit(t *testing.T) {
+	client, _ := setup(t)
+
+	start := time.Unix(1000000, 0)
+
+	_, _, err := client.Measure.Getintradayactivity(context.Background(), AllIntradayActivityFields(), MeasureGetOptions{
+		StartDate: start,
+		EndDate:   start.Add(MaxIntradayActivityRange + time.Second),
+	})
+	if err == nil {
+		t.Error("expected an error for a date range exceeding MaxIntradayActivityRange")
+	}
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		_, _, err := client.Measure.Getintradayactivity(context.Background(), AllIntradayActivityFields(), MeasureGetOptions{
+			StartDate: start,
+			EndDate:   start.Add(-time.Hour),
+		})
+		if err == nil {
+			t.Error("expected an error for end before start")
+		}
+	})
+
+	t.Run("LastUpdate", func(t *testing.T) {
+		_, _, err := client.Measure.Getintradayactivity(context.Background(), AllIntradayActivityFields(), MeasureGetOptions{
+			LastUpdate: start,
+		})
+		if err == nil {
+			t.Error("expected an error for the unsupported LastUpdate filter")
+		}
+	})
+}
+
+func TestMeasureService_Latest(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("offset") {
+		case "":
+			fmt.Fprint(w, `{"status":0,"body":{"more":true,"offset":1,"measuregrps":[{"date":1000,"measures":[{"value":70500,"type":1,"unit":-3}]}]}}`)
+
+		case "1":
+			fmt.Fprint(w, `{"status":0,"body":{"more":false,"timezone":"Europe/Budapest","measuregrps":[{"date":2000,"measures":[{"value":71000,"type":1,"unit":-3}]}]}}`)
+
+		default:
+			t.Errorf("unexpected offset: %s", r.FormValue("offset"))
+		}
+	})
+
+	measure, date, err := client.Measure.Latest(context.Background(), MeasureTypeWeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if measure == nil || measure.Value != 71000 {
+		t.Fatalf("measure = %+v; want value 71000", measure)
+	}
+
+	if !date.Equal(time.Unix(2000, 0)) {
+		t.Errorf("date = %s; want %s", date, time.Unix(2000, 0))
+	}
+
+	if got, want := date.Location().String(), "Europe/Budapest"; got != want {
+		t.Errorf("location = %s; want %s", got, want)
+	}
+}
+
+func TestMeasureService_Getmeas_UnknownMeasureTypes(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("meastypes"), "1,999"; got != want {
+			t.Errorf("meastypes = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{"measuregrps":[{"grpid":1,"measures":[{"value":1,"type":999}]}]}}`)
+	})
+
+	measures, _, err := client.Measure.Getmeas(context.Background(), []MeasureType{MeasureTypeWeight, 999}, MeasureCategoryRealMeasure, MeasureGetOptions{AllowUnknownMeasureTypes: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := measures.MeasureGroups[0].Measures[0].Type, MeasureType(999); got != want {
+		t.Errorf("measure type = %d; want %d", got, want)
+	}
+
+	t.Run("Disallowed", func(t *testing.T) {
+		_, _, err := client.Measure.Getmeas(context.Background(), []MeasureType{999}, MeasureCategoryRealMeasure, MeasureGetOptions{})
+		if err == nil {
+			t.Error("expected an error when only unknown measure types are requested")
+		}
+	})
+}
+
+func TestMeasureService_GetmeasAll(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("offset") {
+		case "":
+			fmt.Fprint(w, `{"status":0,"body":{"more":true,"offset":1,"measuregrps":[{"grpid":1}]}}`)
+
+		case "1":
+			fmt.Fprint(w, `{"status":0,"body":{"more":true,"offset":2,"measuregrps":[{"grpid":2}]}}`)
+
+		case "2":
+			fmt.Fprint(w, `{"status":0,"body":{"more":false,"measuregrps":[{"grpid":3}]}}`)
+
+		default:
+			t.Errorf("unexpected offset: %s", r.FormValue("offset"))
+		}
+	})
+
+	measures, resp, err := client.Measure.GetmeasAll(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(measures.MeasureGroups) != 3 {
+		t.Errorf("got %d measure groups; want 3", len(measures.MeasureGroups))
+	}
+
+	if resp.More {
+		t.Error("response should not indicate more data")
+	}
+
+	t.Run("MaxPages", func(t *testing.T) {
+		measures, resp, err := client.Measure.GetmeasAll(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{MaxPages: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(measures.MeasureGroups) != 2 {
+			t.Errorf("got %d measure groups; want 2", len(measures.MeasureGroups))
+		}
+
+		if !resp.More || resp.Offset != 2 {
+			t.Errorf("response should indicate more data at offset 2")
+		}
+	})
+}
+
+func TestMeasureService_GetmeasAll_PartialResults(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("offset") {
+		case "":
+			fmt.Fprint(w, `{"status":0,"body":{"more":true,"offset":1,"measuregrps":[{"grpid":1}]}}`)
+
+		default:
+			fmt.Fprint(w, `{"status":601,"error":"Too many requests"}`)
+		}
+	})
+
+	measures, _, err := client.Measure.GetmeasAll(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{})
+
+	var perr *PaginationError
+	if !errors.As(err, &perr) {
+		t.Fatalf("error should be a pagination error, got %v", err)
+	}
+
+	if perr.Offset != 1 {
+		t.Errorf("Offset = %d; want 1", perr.Offset)
+	}
+
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("error should wrap ErrRateLimited")
+	}
+
+	if measures == nil || len(measures.MeasureGroups) != 1 {
+		t.Fatalf("partial results should contain the first page")
+	}
+}
+
+func TestMeasureService_GetactivityAll(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v2/measure", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("offset") {
+		case "":
+			fmt.Fprint(w, `{"status":0,"body":{"more":true,"offset":1,"activities":[{"date":"2022-01-01","steps":1}]}}`)
+
+		case "1":
+			fmt.Fprint(w, `{"status":0,"body":{"more":true,"offset":2,"activities":[{"date":"2022-01-02","steps":2}]}}`)
+
+		case "2":
+			fmt.Fprint(w, `{"status":0,"body":{"more":false,"activities":[{"date":"2022-01-03","steps":3}]}}`)
+
+		default:
+			t.Errorf("unexpected offset: %s", r.FormValue("offset"))
+		}
+	})
+
+	activities, resp, err := client.Measure.GetactivityAll(context.Background(), AllActivityFields(), ActivityGetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(activities.Activities) != 3 {
+		t.Fatalf("got %d activities; want 3", len(activities.Activities))
+	}
+
+	for i, activity := range activities.Activities {
+		if activity.Steps != i+1 {
+			t.Errorf("activities[%d].Steps = %d; want %d", i, activity.Steps, i+1)
+		}
+	}
+
+	if resp.More {
+		t.Error("response should not indicate more data")
+	}
+
+	t.Run("MaxPages", func(t *testing.T) {
+		activities, resp, err := client.Measure.GetactivityAll(context.Background(), AllActivityFields(), ActivityGetOptions{MaxPages: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(activities.Activities) != 2 {
+			t.Errorf("got %d activities; want 2", len(activities.Activities))
+		}
+
+		if !resp.More || resp.Offset != 2 {
+			t.Errorf("response should indicate more data at offset 2")
+		}
+	})
+}
+
+func TestMeasureService_GetworkoutsAll(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v2/measure", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("offset") {
+		case "":
+			fmt.Fprint(w, `{"status":0,"body":{"more":true,"offset":1,"series":[{"category":1}]}}`)
+
+		case "1":
+			fmt.Fprint(w, `{"status":0,"body":{"more":false,"series":[{"category":2}]}}`)
+
+		default:
+			t.Errorf("unexpected offset: %s", r.FormValue("offset"))
+		}
+	})
+
+	workouts, resp, err := client.Measure.GetworkoutsAll(context.Background(), AllWorkoutFields(), ActivityGetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(workouts.Series) != 2 {
+		t.Errorf("got %d workouts; want 2", len(workouts.Series))
+	}
+
+	if resp.More {
+		t.Error("response should not indicate more data")
+	}
+}
+
+func TestMeasureService_GetmeasCategories(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"status":0,"body":{"measuregrps":[{"grpid":%s,"category":%s}]}}`, r.FormValue("category"), r.FormValue("category"))
+	})
+
+	categories := []MeasureCategory{MeasureCategoryRealMeasure, MeasureCategoryUserObjective}
+
+	measures, _, err := client.Measure.GetmeasCategories(context.Background(), AllMeasureTypes(), categories, MeasureGetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(measures.MeasureGroups) != 2 {
+		t.Fatalf("got %d measure groups; want 2", len(measures.MeasureGroups))
+	}
+
+	for i, category := range categories {
+		if got := measures.MeasureGroups[i].Category; got != category {
+			t.Errorf("measure group %d category = %d; want %d", i, got, category)
+		}
+	}
+}
+
+func TestMeasureService_GetmeasCategories_PartialResults(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.FormValue("category") == "1":
+			fmt.Fprint(w, `{"status":0,"body":{"measuregrps":[{"grpid":1,"category":1}]}}`)
+
+		case r.FormValue("offset") == "":
+			fmt.Fprint(w, `{"status":0,"body":{"more":true,"offset":1,"measuregrps":[{"grpid":2,"category":2}]}}`)
+
+		default:
+			fmt.Fprint(w, `{"status":601,"error":"Too many requests"}`)
+		}
+	})
+
+	categories := []MeasureCategory{MeasureCategoryRealMeasure, MeasureCategoryUserObjective}
+
+	measures, _, err := client.Measure.GetmeasCategories(context.Background(), AllMeasureTypes(), categories, MeasureGetOptions{})
+
+	var perr *PaginationError
+	if !errors.As(err, &perr) {
+		t.Fatalf("error should be a pagination error, got %v", err)
+	}
+
+	if perr.Offset != 1 {
+		t.Errorf("Offset = %d; want 1", perr.Offset)
+	}
+
+	if measures == nil || len(measures.MeasureGroups) != 2 {
+		t.Fatalf("partial results should contain the first category and the first page of the second one")
+	}
+}
+
+func TestMeasureService_GetmeasSince(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("lastupdate"), "1641038400"; got != want {
+			t.Errorf("lastupdate = %q; want %q", got, want)
+		}
+
+		if r.FormValue("offset") == "" {
+			fmt.Fprint(w, `{"status":0,"body":{"updatetime":1641042000,"more":true,"offset":1,"measuregrps":[{"grpid":1}]}}`)
+
+			return
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{"updatetime":1641042000,"measuregrps":[{"grpid":2}]}}`)
+	})
+
+	measures, cursor, err := client.Measure.GetmeasSince(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, time.Unix(1641038400, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(measures.MeasureGroups), 2; got != want {
+		t.Errorf("got %d measure groups; want %d", got, want)
+	}
+
+	if got, want := cursor.Unix(), int64(1641042000); got != want {
+		t.Errorf("cursor = %d; want %d", got, want)
+	}
+}
+
+func TestMeasureService_GetmeasSince_PartialResults(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("offset") == "" {
+			fmt.Fprint(w, `{"status":0,"body":{"updatetime":1641042000,"more":true,"offset":1,"measuregrps":[{"grpid":1}]}}`)
+
+			return
+		}
+
+		fmt.Fprint(w, `{"status":601,"error":"Too many requests"}`)
+	})
+
+	since := time.Unix(1641038400, 0)
+
+	measures, cursor, err := client.Measure.GetmeasSince(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, since)
+
+	var perr *PaginationError
+	if !errors.As(err, &perr) {
+		t.Fatalf("error should be a pagination error, got %v", err)
+	}
+
+	if measures == nil || len(measures.MeasureGroups) != 1 {
+		t.Fatalf("partial results should contain the first page")
+	}
+
+	if !cursor.Equal(since) {
+		t.Errorf("cursor = %s; want %s", cursor, since)
+	}
+}
+
+func TestMeasureService_GetmeasRange(t *testing.T) {
+	client, mux := setup(t)
+
+	var windows [][2]string
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		windows = append(windows, [2]string{r.FormValue("startdate"), r.FormValue("enddate")})
+
+		// The group at the boundary of the windows is returned twice
+		fmt.Fprintf(w, `{"status":0,"body":{"updatetime":%s,"measuregrps":[{"grpid":%s},{"grpid":%s}]}}`, r.FormValue("enddate"), r.FormValue("startdate"), r.FormValue("enddate"))
+	})
+
+	start := time.Unix(0, 0)
+	end := start.Add(50 * 24 * time.Hour)
+
+	measures, _, err := client.Measure.GetmeasRange(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, start, end, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantWindows := [][2]string{{"0", "2592000"}, {"2592000", "4320000"}}
+	if !reflect.DeepEqual(windows, wantWindows) {
+		t.Errorf("windows = %v; want %v", windows, wantWindows)
+	}
+
+	var groupIDs []int64
+	for _, group := range measures.MeasureGroups {
+		groupIDs = append(groupIDs, group.GroupID)
+	}
+
+	if want := []int64{0, 2592000, 4320000}; !reflect.DeepEqual(groupIDs, want) {
+		t.Errorf("group IDs = %v; want %v", groupIDs, want)
+	}
+
+	if got, want := measures.UpdateTime, 4320000; got != want {
+		t.Errorf("UpdateTime = %d; want %d", got, want)
+	}
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		_, _, err := client.Measure.GetmeasRange(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, end, start, 0)
+		if err == nil {
+			t.Error("expected an error for end before start")
+		}
+	})
+}
+
+func TestMeasureService_GetmeasRange_PartialResults(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.FormValue("startdate") == "0":
+			fmt.Fprint(w, `{"status":0,"body":{"measuregrps":[{"grpid":1}]}}`)
+
+		case r.FormValue("offset") == "":
+			fmt.Fprint(w, `{"status":0,"body":{"more":true,"offset":1,"measuregrps":[{"grpid":2}]}}`)
+
+		default:
+			fmt.Fprint(w, `{"status":601,"error":"Too many requests"}`)
+		}
+	})
+
+	start := time.Unix(0, 0)
+	end := start.Add(50 * 24 * time.Hour)
+
+	measures, _, err := client.Measure.GetmeasRange(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, start, end, 0)
+
+	var perr *PaginationError
+	if !errors.As(err, &perr) {
+		t.Fatalf("error should be a pagination error, got %v", err)
+	}
+
+	if perr.Offset != 1 {
+		t.Errorf("Offset = %d; want 1", perr.Offset)
+	}
+
+	if measures == nil || len(measures.MeasureGroups) != 2 {
+		t.Fatalf("partial results should contain the first window and the first page of the second one")
+	}
+}
+
+func TestMeasureService_DeviceIDFilter(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{"measuregrps":[{"grpid":1,"deviceid":"a"},{"grpid":2,"deviceid":"b"}]}}`)
+	})
+
+	mux.HandleFunc("/v2/measure", func(w http.ResponseWriter, r *http.Request) {
+		switch r.FormValue("action") {
+		case "getactivity":
+			fmt.Fprint(w, `{"status":0,"body":{"activities":[{"date":"2022-01-01","deviceid":"a"},{"date":"2022-01-01","deviceid":"b"}]}}`)
+
+		case "getworkouts":
+			fmt.Fprint(w, `{"status":0,"body":{"series":[{"category":1,"deviceid":"a"},{"category":2,"deviceid":"b"}]}}`)
+
+		default:
+			t.Errorf("unexpected action: %s", r.FormValue("action"))
+		}
+	})
+
+	t.Run("Getmeas", func(t *testing.T) {
+		measures, _, err := client.Measure.Getmeas(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{DeviceID: "b"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(measures.MeasureGroups) != 1 || measures.MeasureGroups[0].GroupID != 2 {
+			t.Errorf("measure groups = %+v; want only group 2", measures.MeasureGroups)
+		}
+	})
+
+	t.Run("Getactivity", func(t *testing.T) {
+		activities, _, err := client.Measure.Getactivity(context.Background(), AllActivityFields(), ActivityGetOptions{DeviceID: "a"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(activities.Activities) != 1 || activities.Activities[0].DeviceID != "a" {
+			t.Errorf("activities = %+v; want only activities of device a", activities.Activities)
+		}
+	})
+
+	t.Run("Getworkouts", func(t *testing.T) {
+		workouts, _, err := client.Measure.Getworkouts(context.Background(), AllWorkoutFields(), ActivityGetOptions{DeviceID: "b"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(workouts.Series) != 1 || workouts.Series[0].DeviceID != "b" {
+			t.Errorf("workouts = %+v; want only workouts of device b", workouts.Series)
+		}
+	})
+}
+
+func TestMeasureService_Getworkouts_Categories(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v2/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{"series":[{"category":1,"startdate":1},{"category":2,"startdate":2},{"category":7,"startdate":3}]}}`)
+	})
+
+	workouts, _, err := client.Measure.Getworkouts(context.Background(), AllWorkoutFields(), ActivityGetOptions{}, WithCategories(WorkoutCategoryRun, WorkoutCategorySwimming))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var categories []WorkoutCategory
+	for _, workout := range workouts.Series {
+		categories = append(categories, workout.Category)
+	}
+
+	if want := []WorkoutCategory{WorkoutCategoryRun, WorkoutCategorySwimming}; !reflect.DeepEqual(categories, want) {
+		t.Errorf("categories = %v; want %v", categories, want)
+	}
+}
+
+func TestMeasureService_Getmeas_UpdateTime(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"Int", `{"status":0,"body":{"updatetime":1641042000,"measuregrps":[]}}`},
+		{"String", `{"status":0,"body":{"updatetime":"1641042000","measuregrps":[]}}`},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			client, mux := setup(t)
+
+			mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, test.body)
+			})
+
+			measures, _, err := client.Measure.Getmeas(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := measures.UpdateTime, 1641042000; got != want {
+				t.Errorf("UpdateTime = %d; want %d", got, want)
+			}
+		})
+	}
+
+	for _, body := range []string{
+		`{"status":0,"body":{"updatetime":"yesterday","measuregrps":[]}}`,
+		`{"status":0,"body":{"updatetime":"","measuregrps":[]}}`,
+		`{"status":0,"body":{"updatetime":" ","measuregrps":[]}}`,
+		`{"status":0,"body":{"updatetime":1641042000,"measuregrps":[{"grpid":1,"category":""}]}}`,
+	} {
+		body := body
+
+		t.Run("Invalid", func(t *testing.T) {
+			client, mux := setup(t)
+
+			mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, body)
+			})
+
+			_, _, err := client.Measure.Getmeas(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{})
+
+			var derr *DecodeError
+			if !errors.As(err, &derr) {
+				t.Errorf("error should be a decode error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMeasureService_Getmeas_TimeZone(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{"updatetime":1641042000,"timezone":"Europe/Budapest","measuregrps":[{"grpid":1,"date":1641038400,"created":1641038460}]}}`)
+	})
+
+	measures, _, err := client.Measure.Getmeas(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := measures.UpdatedAt().Format(time.RFC3339), "2022-01-01T14:00:00+01:00"; got != want {
+		t.Errorf("UpdatedAt() = %s; want %s", got, want)
+	}
+
+	if got, want := measures.Updated(), measures.UpdatedAt(); !got.Equal(want) {
+		t.Errorf("Updated() = %s; want %s", got, want)
+	}
+
+	group := measures.MeasureGroups[0]
+
+	if got, want := group.Time().Format(time.RFC3339), "2022-01-01T13:00:00+01:00"; got != want {
+		t.Errorf("Time() = %s; want %s", got, want)
+	}
+
+	if got, want := group.Created().Format(time.RFC3339), "2022-01-01T13:01:00+01:00"; got != want {
+		t.Errorf("Created() = %s; want %s", got, want)
+	}
+}
+
+func TestMeasures_Latest(t *testing.T) {
+	measures := Measures{
+		TimeZone: "Europe/Budapest",
+		MeasureGroups: []MeasureGroup{
+			{Date: 1641038400, Category: MeasureCategoryRealMeasure, Measures: []Measure{{Value: 70, Type: MeasureTypeWeight}, {Value: 60, Type: MeasureTypeHeartPulse}}},
+			{Date: 1641042000, Category: MeasureCategoryRealMeasure, Measures: []Measure{{Value: 71, Type: MeasureTypeWeight}}},
+			{Date: 1641045600, Category: MeasureCategoryUserObjective, Measures: []Measure{{Value: 65, Type: MeasureTypeWeight}}},
+			{Date: 1641034800, Category: MeasureCategoryRealMeasure, Measures: []Measure{{Value: 69, Type: MeasureTypeWeight}}},
+		},
+	}
+
+	measure, date, ok := measures.Latest(MeasureTypeWeight)
+	if !ok {
+		t.Fatal("expected a weight measure")
+	}
+
+	if got, want := measure.Value, 71; got != want {
+		t.Errorf("value = %d; want %d", got, want)
+	}
+
+	if got, want := date.Format(time.RFC3339), "2022-01-01T14:00:00+01:00"; got != want {
+		t.Errorf("date = %s; want %s", got, want)
+	}
+
+	if _, _, ok := measures.Latest(MeasureTypeHeight); ok {
+		t.Error("did not expect a height measure")
+	}
+
+	t.Run("LatestAll", func(t *testing.T) {
+		latest := measures.LatestAll()
+
+		want := map[MeasureType]Measure{
+			MeasureTypeWeight:     {Value: 71, Type: MeasureTypeWeight},
+			MeasureTypeHeartPulse: {Value: 60, Type: MeasureTypeHeartPulse},
+		}
+
+		if !reflect.DeepEqual(latest, want) {
+			t.Errorf("LatestAll() = %v; want %v", latest, want)
+		}
+	})
+}
+
+func TestMeasures_FilterByType(t *testing.T) {
+	measures := Measures{
+		TimeZone: "Europe/Budapest",
+		MeasureGroups: []MeasureGroup{
+			{GroupID: 1, Measures: []Measure{{Value: 70, Type: MeasureTypeWeight}, {Value: 60, Type: MeasureTypeHeartPulse}}},
+			{GroupID: 2, Measures: []Measure{{Value: 120, Type: MeasureTypeSystolicBP}}},
+		},
+	}
+
+	filtered := measures.FilterByType(MeasureTypeWeight)
+
+	want := Measures{
+		TimeZone: "Europe/Budapest",
+		MeasureGroups: []MeasureGroup{
+			{GroupID: 1, Measures: []Measure{{Value: 70, Type: MeasureTypeWeight}}},
+		},
+	}
+
+	if !reflect.DeepEqual(filtered, want) {
+		t.Errorf("FilterByType() = %+v; want %+v", filtered, want)
+	}
+
+	if got, want := len(measures.MeasureGroups[0].Measures), 2; got != want {
+		t.Errorf("original measure group has %d measures; want %d", got, want)
+	}
+}
+
+func TestMeasures_RealMeasuresAndObjectives(t *testing.T) {
+	measures := Measures{
+		MeasureGroups: []MeasureGroup{
+			{GroupID: 1, Category: MeasureCategoryRealMeasure},
+			{GroupID: 2, Category: MeasureCategoryUserObjective},
+			{GroupID: 3, Category: MeasureCategoryRealMeasure},
+		},
+	}
+
+	groupIDs := func(m Measures) []int64 {
+		var ids []int64
+		for _, group := range m.MeasureGroups {
+			ids = append(ids, group.GroupID)
+		}
+
+		return ids
+	}
+
+	if got, want := groupIDs(measures.RealMeasures()), []int64{1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RealMeasures() = %v; want %v", got, want)
+	}
+
+	if got, want := groupIDs(measures.Objectives()), []int64{2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Objectives() = %v; want %v", got, want)
+	}
+
+	if got, want := len(measures.MeasureGroups), 3; got != want {
+		t.Errorf("original measures have %d groups; want %d", got, want)
+	}
+}
+
+func TestMeasures_Group(t *testing.T) {
+	measures := Measures{
+		MeasureGroups: []MeasureGroup{{GroupID: 1}, {GroupID: 2, DeviceID: "abc"}},
+	}
+
+	group, ok := measures.Group(2)
+	if !ok {
+		t.Fatal("group 2 should be found")
+	}
+
+	if got, want := group.DeviceID, "abc"; got != want {
+		t.Errorf("device ID = %q; want %q", got, want)
+	}
+
+	if _, ok := measures.Group(3); ok {
+		t.Error("group 3 should not be found")
+	}
+}
+
+func TestMeasure_FloatValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		measure Measure
+		want    float64
+	}{
+		{"Weight", Measure{Value: 70500, Type: MeasureTypeWeight, Unit: -3}, 70.5},
+		{"Height", Measure{Value: 178, Type: MeasureTypeHeight, Unit: -2}, 1.78},
+		{"Temperature", Measure{Value: 3650, Type: MeasureTypeBodyTemp, Unit: -2}, 36.5},
+		{"HeartPulse", Measure{Value: 62, Type: MeasureTypeHeartPulse, Unit: 0}, 62},
+		{"PositiveExponent", Measure{Value: 7, Type: MeasureTypeWeight, Unit: 2}, 700},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.measure.FloatValue(); math.Abs(got-test.want) > 1e-9 {
+				t.Errorf("FloatValue() = %v; want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWorkout_Times(t *testing.T) {
+	workout := Workout{
+		Timezone:  "Europe/Budapest",
+		Startdate: 1641038400, // 2022-01-01T12:00:00Z
+		Enddate:   1641042000,
+	}
+
+	if got, want := workout.StartTime().Format(time.RFC3339), "2022-01-01T13:00:00+01:00"; got != want {
+		t.Errorf("StartTime() = %s; want %s", got, want)
+	}
+
+	if got, want := workout.EndTime().Format(time.RFC3339), "2022-01-01T14:00:00+01:00"; got != want {
+		t.Errorf("EndTime() = %s; want %s", got, want)
+	}
+
+	if got, want := workout.Duration(), time.Hour; got != want {
+		t.Errorf("Duration() = %s; want %s", got, want)
+	}
+
+	t.Run("ActiveDuration", func(t *testing.T) {
+		tests := []struct {
+			name string
+			data WorkoutData
+			want time.Duration
+		}{
+			{"NoPause", WorkoutData{}, time.Hour},
+			{"Pause", WorkoutData{PauseDuration: 600}, 50 * time.Minute},
+			{"AlgoPause", WorkoutData{PauseDuration: 600, AlgoPauseDuration: 1200}, 40 * time.Minute},
+			{"Clamped", WorkoutData{PauseDuration: 7200}, 0},
+		}
+
+		for _, test := range tests {
+			test := test
+
+			t.Run(test.name, func(t *testing.T) {
+				workout := workout
+				workout.Data = test.data
+
+				if got := workout.ActiveDuration(); got != test.want {
+					t.Errorf("ActiveDuration() = %s; want %s", got, test.want)
+				}
+			})
+		}
+	})
+
+	t.Run("InvalidTimezone", func(t *testing.T) {
+		workout := workout
+		workout.Timezone = "Invalid/Timezone"
+
+		if got := workout.StartTime().Location(); got != time.UTC {
+			t.Errorf("location = %s; want UTC", got)
+		}
+	})
+}
+
+func TestActivities_FillDays(t *testing.T) {
+	activities := Activities{
+		Activities: []Activity{
+			{Date: "2022-01-03", Steps: 3000},
+			{Date: "2022-01-01", Steps: 1000},
+			{Date: "2021-12-31", Steps: 500},
+		},
+	}
+
+	loc, err := time.LoadLocation("Europe/Budapest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, loc)
+	end := time.Date(2022, 1, 4, 12, 0, 0, 0, loc)
+
+	filled := activities.FillDays(start, end, loc)
+
+	want := []Activity{
+		{Date: "2022-01-01", Steps: 1000},
+		{Date: "2022-01-02", Timezone: "Europe/Budapest", Synthetic: true},
+		{Date: "2022-01-03", Steps: 3000},
+		{Date: "2022-01-04", Timezone: "Europe/Budapest", Synthetic: true},
+	}
+
+	if !reflect.DeepEqual(filled, want) {
+		t.Errorf("FillDays() = %+v; want %+v", filled, want)
+	}
+}
+
+func TestActivity_Day(t *testing.T) {
+	t.Run("Timezone", func(t *testing.T) {
+		day, err := Activity{Date: "2022-01-01", Timezone: "Europe/Budapest"}.Day()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := day.Format(time.RFC3339), "2022-01-01T00:00:00+01:00"; got != want {
+			t.Errorf("Day() = %s; want %s", got, want)
+		}
+	})
+
+	t.Run("EmptyTimezone", func(t *testing.T) {
+		day, err := Activity{Date: "2022-01-01"}.Day()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := day.Format(time.RFC3339), "2022-01-01T00:00:00Z"; got != want {
+			t.Errorf("Day() = %s; want %s", got, want)
+		}
+	})
+
+	t.Run("InvalidDate", func(t *testing.T) {
+		if _, err := (Activity{Date: "2022/01/01"}).Day(); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestParseIntradayTimestamp(t *testing.T) {
+	got, err := ParseIntradayTimestamp("1641038400")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := time.Unix(1641038400, 0); !got.Equal(want) {
+		t.Errorf("ParseIntradayTimestamp() = %s; want %s", got, want)
+	}
+
+	if _, err := ParseIntradayTimestamp("invalid"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestMeasureGetOptions_Validate(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		opts  MeasureGetOptions
+		valid bool
+	}{
+		{"Empty", MeasureGetOptions{}, true},
+		{"DateRange", MeasureGetOptions{StartDate: now, EndDate: now}, true},
+		{"LastUpdate", MeasureGetOptions{LastUpdate: now}, true},
+		{"LastUpdateWithDateRange", MeasureGetOptions{LastUpdate: now, StartDate: now, EndDate: now}, false},
+		{"LastUpdateWithStartDate", MeasureGetOptions{LastUpdate: now, StartDate: now}, false},
+		{"StartDateOnly", MeasureGetOptions{StartDate: now}, false},
+		{"EndDateOnly", MeasureGetOptions{EndDate: now}, false},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.opts.Validate(); (err == nil) != test.valid {
+				t.Errorf("Validate() = %v; want valid: %t", err, test.valid)
+			}
+		})
+	}
+}
+
+func TestIntradayActivities_Sorted(t *testing.T) {
+	activities := IntradayActivities{
+		Series: map[string]IntradayActivity{
+			"1641042000": {Steps: 2},
+			"1641038400": {Steps: 1},
+			"1641045600": {Steps: 3},
+		},
+	}
+
+	samples, err := activities.Sorted()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(samples) != 3 {
+		t.Fatalf("got %d samples; want 3", len(samples))
+	}
+
+	for i, sample := range samples {
+		if got, want := sample.Steps, i+1; got != want {
+			t.Errorf("samples[%d].Steps = %d; want %d", i, got, want)
+		}
+	}
+
+	if got, want := samples[0].Time, time.Unix(1641038400, 0); !got.Equal(want) {
+		t.Errorf("samples[0].Time = %s; want %s", got, want)
+	}
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		_, err := IntradayActivities{Series: map[string]IntradayActivity{"invalid": {}}}.Sorted()
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
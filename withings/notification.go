@@ -0,0 +1,119 @@
+package withings
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Notification is sent by Withings to the callback URL of a subscription when new data is available.
+//
+// Withings API docs: https://developer.withings.com/developer-guide/v3/data-api/keep-user-data-up-to-date/#notification-format
+type Notification struct {
+	UserID int64
+	Appli  NotifyAppli
+
+	// StartDate and EndDate is the time range of the new data (if available).
+	StartDate time.Time
+	EndDate   time.Time
+
+	// Date of the new data (in YYYY-MM-DD format) for daily aggregated data (eg. activities).
+	Date string
+
+	// DeviceID of the device the notification is about (if any).
+	DeviceID string
+}
+
+// ParseNotification parses a notification sent by Withings to a callback URL.
+//
+// Withings API docs: https://developer.withings.com/developer-guide/v3/data-api/keep-user-data-up-to-date/#notification-format
+func ParseNotification(r *http.Request) (*Notification, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	userID, err := strconv.ParseInt(r.Form.Get("userid"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid userid %q: %w", r.Form.Get("userid"), err)
+	}
+
+	appli, err := strconv.Atoi(r.Form.Get("appli"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid appli %q: %w", r.Form.Get("appli"), err)
+	}
+
+	notification := &Notification{
+		UserID:   userID,
+		Appli:    NotifyAppli(appli),
+		Date:     r.Form.Get("date"),
+		DeviceID: r.Form.Get("deviceid"),
+	}
+
+	if notification.StartDate, err = parseNotificationTime(r.Form.Get("startdate")); err != nil {
+		return nil, fmt.Errorf("invalid startdate: %w", err)
+	}
+
+	if notification.EndDate, err = parseNotificationTime(r.Form.Get("enddate")); err != nil {
+		return nil, fmt.Errorf("invalid enddate: %w", err)
+	}
+
+	return notification, nil
+}
+
+// parseNotificationTime parses an optional unix timestamp.
+func parseNotificationTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	timestamp, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(timestamp, 0), nil
+}
+
+// NotificationHandler returns an http.Handler that parses notifications and passes them to fn.
+//
+// The handler responds with:
+//   - 200 to HEAD and GET requests (sent by Withings to validate the callback URL)
+//   - 400 if the notification cannot be parsed
+//   - 500 if fn returns an error
+//   - 200 otherwise
+//
+// Withings expects a quick response, so fn should not do long running work synchronously.
+func NotificationHandler(fn func(ctx context.Context, notification *Notification) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodHead, http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+
+			return
+
+		case http.MethodPost:
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		notification, err := ParseNotification(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		if err := fn(r.Context(), notification); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
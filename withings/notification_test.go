@@ -0,0 +1,101 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newNotificationRequest(form url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return req
+}
+
+func TestParseNotification(t *testing.T) {
+	req := newNotificationRequest(url.Values{
+		"userid":    {"363"},
+		"appli":     {"1"},
+		"startdate": {"1641038400"},
+		"enddate":   {"1641042000"},
+	})
+
+	notification, err := ParseNotification(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Notification{
+		UserID:    363,
+		Appli:     NotifyAppliWeight,
+		StartDate: time.Unix(1641038400, 0),
+		EndDate:   time.Unix(1641042000, 0),
+	}
+
+	if notification.UserID != want.UserID || notification.Appli != want.Appli ||
+		!notification.StartDate.Equal(want.StartDate) || !notification.EndDate.Equal(want.EndDate) {
+		t.Errorf("notification = %+v; want %+v", notification, want)
+	}
+
+	t.Run("InvalidUserID", func(t *testing.T) {
+		_, err := ParseNotification(newNotificationRequest(url.Values{"appli": {"1"}}))
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestNotificationHandler(t *testing.T) {
+	var received *Notification
+
+	handler := NotificationHandler(func(ctx context.Context, notification *Notification) error {
+		received = notification
+
+		if notification.Appli == NotifyAppliSleep {
+			return errors.New("something went wrong")
+		}
+
+		return nil
+	})
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		code int
+	}{
+		{"Validation", httptest.NewRequest(http.MethodHead, "/callback", nil), http.StatusOK},
+		{"OK", newNotificationRequest(url.Values{"userid": {"363"}, "appli": {"16"}}), http.StatusOK},
+		{"Invalid", newNotificationRequest(url.Values{"userid": {"363"}}), http.StatusBadRequest},
+		{"HandlerError", newNotificationRequest(url.Values{"userid": {"363"}, "appli": {"44"}}), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, test.req)
+
+			if got, want := rec.Code, test.code; got != want {
+				t.Errorf("status code = %d; want %d", got, want)
+			}
+		})
+	}
+
+	t.Run("Received", func(t *testing.T) {
+		received = nil
+
+		handler.ServeHTTP(httptest.NewRecorder(), newNotificationRequest(url.Values{"userid": {"363"}, "appli": {"16"}, "date": {"2022-01-01"}}))
+
+		if received == nil || received.Date != "2022-01-01" {
+			t.Errorf("received notification = %+v; want date 2022-01-01", received)
+		}
+	})
+}
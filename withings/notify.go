@@ -1,7 +1,401 @@
 package withings
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
 // NotifyService handles communication with the notification related
 // methods of the Withings API.
 //
 // Withings API docs: https://developer.withings.com/api-reference/#tag/notify
 type NotifyService service
+
+// NotifyAppli is a category of data notifications can be subscribed to.
+//
+// Withings API docs: https://developer.withings.com/developer-guide/v3/data-api/keep-user-data-up-to-date/#notification-categories
+type NotifyAppli int
+
+// NotifyAppli values
+const (
+	NotifyAppliWeight              NotifyAppli = 1  // New weight-related data.
+	NotifyAppliTemperature         NotifyAppli = 2  // New temperature related data.
+	NotifyAppliPressure            NotifyAppli = 4  // New pressure related data (blood pressure, heart rate, SpO2).
+	NotifyAppliActivity            NotifyAppli = 16 // New activity-related data.
+	NotifyAppliSleep               NotifyAppli = 44 // New sleep-related data.
+	NotifyAppliUserActions         NotifyAppli = 46 // New action on user profile.
+	NotifyAppliBedIn               NotifyAppli = 50 // Bed in event.
+	NotifyAppliBedOut              NotifyAppli = 51 // Bed out event.
+	NotifyAppliInflateDone         NotifyAppli = 52 // Inflate done event (Sleep Analyzer).
+	NotifyAppliNoAccountAssociated NotifyAppli = 53 // Device is not associated with any account.
+	NotifyAppliECG                 NotifyAppli = 54 // New ECG data.
+	NotifyAppliECGFailed           NotifyAppli = 55 // ECG measure failed.
+	NotifyAppliGlucose             NotifyAppli = 58 // New glucose data.
+)
+
+var validNotifyAppliValues = map[NotifyAppli]struct{}{
+	NotifyAppliWeight:              {},
+	NotifyAppliTemperature:         {},
+	NotifyAppliPressure:            {},
+	NotifyAppliActivity:            {},
+	NotifyAppliSleep:               {},
+	NotifyAppliUserActions:         {},
+	NotifyAppliBedIn:               {},
+	NotifyAppliBedOut:              {},
+	NotifyAppliInflateDone:         {},
+	NotifyAppliNoAccountAssociated: {},
+	NotifyAppliECG:                 {},
+	NotifyAppliECGFailed:           {},
+	NotifyAppliGlucose:             {},
+}
+
+// IsValid checks if v is a valid NotifyAppli.
+func (v NotifyAppli) IsValid() bool {
+	_, ok := validNotifyAppliValues[v]
+
+	return ok
+}
+
+// AllNotifyApplis returns the list of all NotifyAppli values.
+func AllNotifyApplis() []NotifyAppli {
+	return []NotifyAppli{
+		NotifyAppliWeight,
+		NotifyAppliTemperature,
+		NotifyAppliPressure,
+		NotifyAppliActivity,
+		NotifyAppliSleep,
+		NotifyAppliUserActions,
+		NotifyAppliBedIn,
+		NotifyAppliBedOut,
+		NotifyAppliInflateDone,
+		NotifyAppliNoAccountAssociated,
+		NotifyAppliECG,
+		NotifyAppliECGFailed,
+		NotifyAppliGlucose,
+	}
+}
+
+// NotifyProfile is a notification subscription.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/notify-list
+type NotifyProfile struct {
+	Appli       NotifyAppli `json:"appli"`
+	CallbackURL string      `json:"callbackurl"`
+	Expires     int64       `json:"expires"`
+	Comment     string      `json:"comment"`
+}
+
+// Subscribe creates a notification subscription to receive data updates of the given category.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/notify-subscribe
+func (s *NotifyService) Subscribe(ctx context.Context, callbackURL string, appli NotifyAppli, comment string) (*Response, error) {
+	if s == nil || s.client == nil {
+		return nil, errClientNotInitialized
+	}
+
+	if !appli.IsValid() {
+		return nil, errors.New("invalid appli")
+	}
+
+	const urlPath = "notify"
+
+	form := url.Values{
+		"action":      {"subscribe"},
+		"callbackurl": {callbackURL},
+		"appli":       {fmt.Sprintf("%d", appli)},
+	}
+
+	if comment != "" {
+		form.Add("comment", comment)
+	}
+
+	return s.client.PostForm(ctx, urlPath, form, nil)
+}
+
+// SubscribeAll subscribes to notifications of every given category.
+//
+// It continues with the remaining categories if a subscription fails,
+// and returns the categories that were subscribed to successfully
+// along with a *SubscribeAllError aggregating the errors (if any).
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/notify-subscribe
+func (s *NotifyService) SubscribeAll(ctx context.Context, callbackURL string, applis []NotifyAppli, comment string) ([]NotifyAppli, error) {
+	if s == nil || s.client == nil {
+		return nil, errClientNotInitialized
+	}
+
+	var (
+		subscribed []NotifyAppli
+		errs       map[NotifyAppli]error
+	)
+
+	for _, appli := range applis {
+		if err := ctx.Err(); err != nil {
+			return subscribed, err
+		}
+
+		_, err := s.Subscribe(ctx, callbackURL, appli, comment)
+		if err != nil {
+			if errs == nil {
+				errs = map[NotifyAppli]error{}
+			}
+
+			errs[appli] = err
+
+			continue
+		}
+
+		subscribed = append(subscribed, appli)
+	}
+
+	if errs != nil {
+		return subscribed, &SubscribeAllError{Errors: errs}
+	}
+
+	return subscribed, nil
+}
+
+// SubscribeAllError aggregates the errors of failed subscriptions returned by SubscribeAll.
+type SubscribeAllError struct {
+	// Errors contains the error of each category that could not be subscribed to.
+	Errors map[NotifyAppli]error
+}
+
+func (e *SubscribeAllError) Error() string {
+	applis := e.applis()
+
+	msgs := make([]string, 0, len(applis))
+	for _, appli := range applis {
+		msgs = append(msgs, fmt.Sprintf("appli %d: %v", appli, e.Errors[appli]))
+	}
+
+	return fmt.Sprintf("subscribing to %d categories failed: %s", len(applis), strings.Join(msgs, "; "))
+}
+
+// Is reports whether any of the aggregated errors matches target.
+func (e *SubscribeAllError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first aggregated error (in the order of categories) that matches target.
+func (e *SubscribeAllError) As(target interface{}) bool {
+	for _, appli := range e.applis() {
+		if errors.As(e.Errors[appli], target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applis returns the categories of the aggregated errors in ascending order.
+func (e *SubscribeAllError) applis() []NotifyAppli {
+	applis := make([]NotifyAppli, 0, len(e.Errors))
+	for appli := range e.Errors {
+		applis = append(applis, appli)
+	}
+
+	sort.Slice(applis, func(i, j int) bool { return applis[i] < applis[j] })
+
+	return applis
+}
+
+// NotifyReconcileResult describes the changes made by Reconcile.
+type NotifyReconcileResult struct {
+	// Subscribed contains the categories that were subscribed to.
+	Subscribed []NotifyAppli
+
+	// Revoked contains the subscriptions that were revoked.
+	Revoked []NotifyProfile
+}
+
+// Reconcile ensures that the user is subscribed to exactly the desired categories with callbackURL.
+//
+// It lists the existing subscriptions, revokes those not in the desired set
+// or pointing at a different callback URL (eg. a stale development URL),
+// then subscribes to the missing categories.
+//
+// Reconcile stops at the first error and returns the changes made until then.
+func (s *NotifyService) Reconcile(ctx context.Context, callbackURL string, desired []NotifyAppli) (*NotifyReconcileResult, error) {
+	if s == nil || s.client == nil {
+		return nil, errClientNotInitialized
+	}
+
+	wanted := make(map[NotifyAppli]bool, len(desired))
+	for _, appli := range desired {
+		if !appli.IsValid() {
+			return nil, errors.New("invalid appli")
+		}
+
+		wanted[appli] = true
+	}
+
+	profiles, _, err := s.List(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(NotifyReconcileResult)
+	existing := map[NotifyAppli]bool{}
+
+	for _, profile := range profiles {
+		if profile.CallbackURL == callbackURL && wanted[profile.Appli] {
+			existing[profile.Appli] = true
+
+			continue
+		}
+
+		_, err := s.Revoke(ctx, profile.CallbackURL, profile.Appli)
+		if err != nil {
+			return result, err
+		}
+
+		result.Revoked = append(result.Revoked, profile)
+	}
+
+	for _, appli := range desired {
+		if existing[appli] {
+			continue
+		}
+
+		_, err := s.Subscribe(ctx, callbackURL, appli, "")
+		if err != nil {
+			return result, err
+		}
+
+		existing[appli] = true
+		result.Subscribed = append(result.Subscribed, appli)
+	}
+
+	return result, nil
+}
+
+type notifyGetResponse struct {
+	Body NotifyProfile `json:"body"`
+}
+
+// Get returns the notification subscription for a callback URL and category.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/notify-get
+func (s *NotifyService) Get(ctx context.Context, callbackURL string, appli NotifyAppli) (*NotifyProfile, *Response, error) {
+	if s == nil || s.client == nil {
+		return nil, nil, errClientNotInitialized
+	}
+
+	if !appli.IsValid() {
+		return nil, nil, errors.New("invalid appli")
+	}
+
+	const urlPath = "notify"
+
+	form := url.Values{
+		"action":      {"get"},
+		"callbackurl": {callbackURL},
+		"appli":       {fmt.Sprintf("%d", appli)},
+	}
+
+	getResp := new(notifyGetResponse)
+
+	resp, err := s.client.PostForm(ctx, urlPath, form, getResp)
+
+	return &getResp.Body, resp, err
+}
+
+type notifyListResponse struct {
+	Body struct {
+		Profiles []NotifyProfile `json:"profiles"`
+	} `json:"body"`
+}
+
+// List returns the notification subscriptions of the user.
+//
+// If appli is zero, subscriptions of all categories are returned.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/notify-list
+func (s *NotifyService) List(ctx context.Context, appli NotifyAppli) ([]NotifyProfile, *Response, error) {
+	if s == nil || s.client == nil {
+		return nil, nil, errClientNotInitialized
+	}
+
+	if appli != 0 && !appli.IsValid() {
+		return nil, nil, errors.New("invalid appli")
+	}
+
+	const urlPath = "notify"
+
+	form := url.Values{
+		"action": {"list"},
+	}
+
+	if appli != 0 {
+		form.Add("appli", fmt.Sprintf("%d", appli))
+	}
+
+	listResp := new(notifyListResponse)
+
+	resp, err := s.client.PostForm(ctx, urlPath, form, listResp)
+
+	return listResp.Body.Profiles, resp, err
+}
+
+// Update changes the callback URL and/or category of a notification subscription.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/notify-update
+func (s *NotifyService) Update(ctx context.Context, callbackURL string, appli NotifyAppli, newCallbackURL string, newAppli NotifyAppli, comment string) (*Response, error) {
+	if s == nil || s.client == nil {
+		return nil, errClientNotInitialized
+	}
+
+	if !appli.IsValid() || !newAppli.IsValid() {
+		return nil, errors.New("invalid appli")
+	}
+
+	const urlPath = "notify"
+
+	form := url.Values{
+		"action":          {"update"},
+		"callbackurl":     {callbackURL},
+		"appli":           {fmt.Sprintf("%d", appli)},
+		"new_callbackurl": {newCallbackURL},
+		"new_appli":       {fmt.Sprintf("%d", newAppli)},
+	}
+
+	if comment != "" {
+		form.Add("comment", comment)
+	}
+
+	return s.client.PostForm(ctx, urlPath, form, nil)
+}
+
+// Revoke deletes a notification subscription.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/notify-revoke
+func (s *NotifyService) Revoke(ctx context.Context, callbackURL string, appli NotifyAppli) (*Response, error) {
+	if s == nil || s.client == nil {
+		return nil, errClientNotInitialized
+	}
+
+	if !appli.IsValid() {
+		return nil, errors.New("invalid appli")
+	}
+
+	const urlPath = "notify"
+
+	form := url.Values{
+		"action":      {"revoke"},
+		"callbackurl": {callbackURL},
+		"appli":       {fmt.Sprintf("%d", appli)},
+	}
+
+	return s.client.PostForm(ctx, urlPath, form, nil)
+}
@@ -0,0 +1,192 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestNotifyAppli(t *testing.T) {
+	t.Run("AllValid", func(t *testing.T) {
+		for _, v := range AllNotifyApplis() {
+			if !v.IsValid() {
+				t.Errorf("%d is supposed to be a valid NotifyAppli", v)
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if NotifyAppli(0).IsValid() {
+			t.Error("non existent NotifyAppli should not be valid")
+		}
+	})
+}
+
+func TestNotifyService_Subscribe(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/notify", func(w http.ResponseWriter, r *http.Request) {
+		want := map[string]string{
+			"action":      "subscribe",
+			"callbackurl": "https://example.com/callback",
+			"appli":       "44",
+			"comment":     "sleep",
+		}
+
+		for key, value := range want {
+			if got := r.FormValue(key); got != value {
+				t.Errorf("%s = %q; want %q", key, got, value)
+			}
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	_, err := client.Notify.Subscribe(context.Background(), "https://example.com/callback", NotifyAppliSleep, "sleep")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNotifyService_SubscribeAll(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/notify", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("appli") == "16" {
+			fmt.Fprint(w, `{"status":293,"error":"Invalid callback URL"}`)
+
+			return
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	applis := []NotifyAppli{NotifyAppliWeight, NotifyAppliActivity, NotifyAppliSleep}
+
+	subscribed, err := client.Notify.SubscribeAll(context.Background(), "https://example.com/callback", applis, "")
+
+	if want := []NotifyAppli{NotifyAppliWeight, NotifyAppliSleep}; !reflect.DeepEqual(subscribed, want) {
+		t.Errorf("subscribed = %v; want %v", subscribed, want)
+	}
+
+	var subscribeErr *SubscribeAllError
+	if !errors.As(err, &subscribeErr) {
+		t.Fatalf("error = %v; want *SubscribeAllError", err)
+	}
+
+	if _, ok := subscribeErr.Errors[NotifyAppliActivity]; !ok || len(subscribeErr.Errors) != 1 {
+		t.Errorf("errors = %v; want an error for appli %d", subscribeErr.Errors, NotifyAppliActivity)
+	}
+
+	if !errors.Is(err, ErrInvalidParams) {
+		t.Error("error should match ErrInvalidParams")
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("error = %v; want an *ErrorResponse through the aggregate", err)
+	}
+
+	if got, want := errResp.Status, 293; got != want {
+		t.Errorf("Status = %d; want %d", got, want)
+	}
+
+	t.Run("RateLimited", func(t *testing.T) {
+		client, mux := setup(t, WithMaxRetries(0))
+
+		mux.HandleFunc("/notify", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		_, err := client.Notify.SubscribeAll(context.Background(), "https://example.com/callback", applis, "")
+
+		var rateLimitErr *RateLimitError
+		if !errors.As(err, &rateLimitErr) {
+			t.Errorf("error = %v; want a *RateLimitError through the aggregate", err)
+		}
+	})
+}
+
+func TestNotifyService_Reconcile(t *testing.T) {
+	client, mux := setup(t)
+
+	const callbackURL = "https://example.com/callback"
+
+	var actions []string
+
+	mux.HandleFunc("/notify", func(w http.ResponseWriter, r *http.Request) {
+		action := r.FormValue("action")
+
+		if action == "list" {
+			fmt.Fprint(w, `{"status":0,"body":{"profiles":[`+
+				`{"appli":1,"callbackurl":"https://example.com/callback"},`+
+				`{"appli":16,"callbackurl":"https://old.example.com/callback"},`+
+				`{"appli":54,"callbackurl":"https://example.com/callback"}`+
+				`]}}`)
+
+			return
+		}
+
+		actions = append(actions, fmt.Sprintf("%s %s %s", action, r.FormValue("appli"), r.FormValue("callbackurl")))
+
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	desired := []NotifyAppli{NotifyAppliWeight, NotifyAppliActivity, NotifyAppliSleep}
+
+	result, err := client.Notify.Reconcile(context.Background(), callbackURL, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantActions := []string{
+		"revoke 16 https://old.example.com/callback",
+		"revoke 54 https://example.com/callback",
+		"subscribe 16 https://example.com/callback",
+		"subscribe 44 https://example.com/callback",
+	}
+	if !reflect.DeepEqual(actions, wantActions) {
+		t.Errorf("actions = %v; want %v", actions, wantActions)
+	}
+
+	if want := []NotifyAppli{NotifyAppliActivity, NotifyAppliSleep}; !reflect.DeepEqual(result.Subscribed, want) {
+		t.Errorf("subscribed = %v; want %v", result.Subscribed, want)
+	}
+
+	if got, want := len(result.Revoked), 2; got != want {
+		t.Errorf("revoked %d subscriptions; want %d", got, want)
+	}
+}
+
+func TestNotifyService_List(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/notify", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("action"), "list"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{"profiles":[{"appli":1,"callbackurl":"https://example.com/callback","expires":2147483647,"comment":"weight"}]}}`)
+	})
+
+	profiles, _, err := client.Notify.List(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []NotifyProfile{
+		{
+			Appli:       NotifyAppliWeight,
+			CallbackURL: "https://example.com/callback",
+			Expires:     2147483647,
+			Comment:     "weight",
+		},
+	}
+
+	if !reflect.DeepEqual(profiles, want) {
+		t.Errorf("profiles = %+v; want %+v", profiles, want)
+	}
+}
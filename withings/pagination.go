@@ -0,0 +1,73 @@
+package withings
+
+import (
+	"context"
+)
+
+// PageFunc fetches a single page of results starting at offset.
+//
+// It is responsible for collecting the items of the fetched page (eg. by appending them to a slice in its closure)
+// and must return the Response of the page, so the Paginator can follow pagination.
+type PageFunc func(ctx context.Context, offset int) (*Response, error)
+
+// Paginator follows Withings API pagination by calling a PageFunc until there are no more pages.
+//
+// Since the items of a page are collected by the PageFunc,
+// the same Paginator works for every API call that supports pagination:
+//
+//	var all []withings.Workout
+//
+//	p := withings.Paginator{MaxPages: 10}
+//
+//	resp, err := p.Paginate(ctx, func(ctx context.Context, offset int) (*withings.Response, error) {
+//		opts.Offset = offset
+//
+//		workouts, resp, err := client.Measure.Getworkouts(ctx, fields, opts)
+//		if err != nil {
+//			return resp, err
+//		}
+//
+//		all = append(all, workouts.Series...)
+//
+//		return resp, nil
+//	})
+type Paginator struct {
+	// Offset is the offset of the first page.
+	Offset int
+
+	// MaxPages limits the number of pages fetched. Zero means no limit.
+	// If the limit is reached, the returned Response indicates that there is more data to fetch.
+	MaxPages int
+}
+
+// Paginate calls fetch for every page and returns the Response of the last fetched page.
+//
+// If the first page cannot be fetched, the error is returned as is.
+// If fetching a page fails after some pages were fetched successfully
+// (or ctx is canceled between pages), a *PaginationError is returned
+// containing the offset to resume from.
+// Items collected by fetch up to that point can be returned to the caller as partial results.
+func (p Paginator) Paginate(ctx context.Context, fetch PageFunc) (*Response, error) {
+	offset := p.Offset
+
+	for page := 1; ; page++ {
+		resp, err := fetch(ctx, offset)
+		if err != nil {
+			if page == 1 {
+				return resp, err
+			}
+
+			return resp, &PaginationError{Offset: offset, Err: err}
+		}
+
+		if !resp.More || (p.MaxPages > 0 && page >= p.MaxPages) {
+			return resp, nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return resp, &PaginationError{Offset: resp.Offset, Err: err}
+		}
+
+		offset = resp.Offset
+	}
+}
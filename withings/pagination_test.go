@@ -0,0 +1,106 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPaginator_Paginate(t *testing.T) {
+	pages := map[int]*Response{
+		0: {More: true, Offset: 1},
+		1: {More: true, Offset: 2},
+		2: {More: false},
+	}
+
+	tests := []struct {
+		name      string
+		paginator Paginator
+		offsets   []int
+		more      bool
+	}{
+		{"All", Paginator{}, []int{0, 1, 2}, false},
+		{"Offset", Paginator{Offset: 1}, []int{1, 2}, false},
+		{"MaxPages", Paginator{MaxPages: 2}, []int{0, 1}, true},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			var offsets []int
+
+			resp, err := test.paginator.Paginate(context.Background(), func(ctx context.Context, offset int) (*Response, error) {
+				offsets = append(offsets, offset)
+
+				return pages[offset], nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(offsets, test.offsets) {
+				t.Errorf("offsets = %v; want %v", offsets, test.offsets)
+			}
+
+			if resp.More != test.more {
+				t.Errorf("More = %t; want %t", resp.More, test.more)
+			}
+		})
+	}
+}
+
+func TestPaginator_Paginate_Error(t *testing.T) {
+	t.Run("FirstPage", func(t *testing.T) {
+		_, err := Paginator{}.Paginate(context.Background(), func(ctx context.Context, offset int) (*Response, error) {
+			return nil, ErrRateLimited
+		})
+
+		if err != ErrRateLimited { // nolint: errorlint
+			t.Errorf("error = %v; want %v", err, ErrRateLimited)
+		}
+	})
+
+	t.Run("NextPage", func(t *testing.T) {
+		_, err := Paginator{}.Paginate(context.Background(), func(ctx context.Context, offset int) (*Response, error) {
+			if offset == 0 {
+				return &Response{More: true, Offset: 5}, nil
+			}
+
+			return nil, ErrRateLimited
+		})
+
+		var perr *PaginationError
+		if !errors.As(err, &perr) {
+			t.Fatalf("error should be a pagination error, got %v", err)
+		}
+
+		if perr.Offset != 5 {
+			t.Errorf("Offset = %d; want 5", perr.Offset)
+		}
+
+		if !errors.Is(err, ErrRateLimited) {
+			t.Error("error should wrap ErrRateLimited")
+		}
+	})
+
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		_, err := Paginator{}.Paginate(ctx, func(ctx context.Context, offset int) (*Response, error) {
+			cancel()
+
+			return &Response{More: true, Offset: 5}, nil
+		})
+
+		var perr *PaginationError
+		if !errors.As(err, &perr) || perr.Offset != 5 {
+			t.Fatalf("error should be a pagination error at offset 5, got %v", err)
+		}
+
+		if !errors.Is(err, context.Canceled) {
+			t.Error("error should wrap context.Canceled")
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryableError is the default policy deciding whether a failed request should be retried.
+//
+// Rate limited requests (status 601 or HTTP 429), upstream failures reported by the API (eg. timeouts)
+// and network errors that occurred before the request was sent (ie. failing to connect) are retried.
+// Other network errors are not: the request may have reached the API already,
+// and replaying a non-idempotent action (eg. subscribing to notifications) is not safe.
+// Other API errors (eg. invalid tokens or params), decoding errors
+// and context cancellation are not retried either.
+func RetryableError(err error, resp *Response) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if errors.Is(err, ErrRateLimited) || errors.Is(err, ErrUpstreamUnavailable) {
+		return true
+	}
+
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// shouldRetry decides whether a failed request should be retried using the configured policy.
+func (c *Client) shouldRetry(err error, resp *Response) bool {
+	if c.checkRetry != nil {
+		return c.checkRetry(err, resp)
+	}
+
+	return RetryableError(err, resp)
+}
+
+// backoff returns the time to wait before retrying a request for the given attempt (starting from 0).
+func (c *Client) backoff(attempt int) time.Duration {
+	wait := c.retryWaitMin
+
+	for i := 0; i < attempt && (c.retryWaitMax <= 0 || wait < c.retryWaitMax); i++ {
+		wait *= 2
+	}
+
+	if c.retryWaitMax > 0 && wait > c.retryWaitMax {
+		wait = c.retryWaitMax
+	}
+
+	return wait
+}
+
+// retryWait returns the time to wait before retrying a request.
+//
+// It honors the Retry-After header if the server sent one.
+func (c *Client) retryWait(attempt int, resp *Response) time.Duration {
+	if wait, ok := retryAfter(resp); ok {
+		return wait
+	}
+
+	return c.backoff(attempt)
+}
+
+// retryAfterHeaders is the list of headers that may tell when the next request can be sent.
+var retryAfterHeaders = []string{"Retry-After", "X-Next-Request-After"}
+
+// retryAfter parses the Retry-After (or equivalent) header of a response (if any).
+func retryAfter(resp *Response) (time.Duration, bool) {
+	if resp == nil || resp.HttpResponse == nil {
+		return 0, false
+	}
+
+	for _, name := range retryAfterHeaders {
+		header := resp.HttpResponse.Header.Get(name)
+		if header == "" {
+			continue
+		}
+
+		if wait, ok := parseRetryAfter(header); ok {
+			return wait, true
+		}
+	}
+
+	return 0, false
+}
+
+// parseRetryAfter parses a header value that is either a number of seconds or an HTTP date.
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// rewindRequest returns a copy of req that can be sent again.
+//
+// It uses GetBody to replay the request body.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	retryReq := req.Clone(req.Context())
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return retryReq, nil
+	}
+
+	if req.GetBody == nil {
+		return nil, errors.New("request body cannot be replayed")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	retryReq.Body = body
+
+	return retryReq, nil
+}
+
+// sleep waits for d or until ctx is done (whichever comes first).
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	case <-timer.C:
+		return nil
+	}
+}
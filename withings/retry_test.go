@@ -0,0 +1,325 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Do_Retry(t *testing.T) {
+	client, mux := setup(t, WithMaxRetries(2), WithRetryWait(time.Millisecond, 5*time.Millisecond))
+
+	var (
+		requests    int
+		rateLimited = 2
+	)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if got, want := r.FormValue("action"), "getmeas"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		if requests <= rateLimited {
+			fmt.Fprint(w, `{"status":601,"body":{}}`)
+
+			return
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	_, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"getmeas"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 3 {
+		t.Errorf("requests = %d; want 3", requests)
+	}
+
+	t.Run("Exhausted", func(t *testing.T) {
+		requests = 0
+		rateLimited = 10
+
+		_, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"getmeas"}}, nil)
+		if !errors.Is(err, ErrRateLimited) {
+			t.Errorf("error = %v; want %v", err, ErrRateLimited)
+		}
+
+		if requests != 3 {
+			t.Errorf("requests = %d; want 3", requests)
+		}
+	})
+}
+
+func TestClient_backoff(t *testing.T) {
+	client := &Client{
+		retryWaitMin: time.Second,
+		retryWaitMax: 5 * time.Second,
+	}
+
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if got := client.backoff(attempt); got != want {
+			t.Errorf("backoff(%d) = %s; want %s", attempt, got, want)
+		}
+	}
+}
+
+func TestNewClientWithDefaults(t *testing.T) {
+	client := NewClientWithDefaults(http.DefaultClient, WithMaxRetries(1))
+
+	if client.MaxRetries() != 1 {
+		t.Errorf("options should override defaults")
+	}
+
+	if client.RequestTimeout() != defaultRequestTimeout {
+		t.Errorf("RequestTimeout = %s; want %s", client.RequestTimeout(), defaultRequestTimeout)
+	}
+}
+
+func TestClient_Do_RetryHTTP429(t *testing.T) {
+	client, mux := setup(t, WithMaxRetries(1), WithRetryWait(time.Hour, 0)) // Retry-After should take precedence
+
+	var requests int
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	_, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"getmeas"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 2 {
+		t.Errorf("requests = %d; want 2", requests)
+	}
+}
+
+func TestClient_Do_RetryRequestMutator(t *testing.T) {
+	client, mux := setup(t, WithMaxRetries(2), WithRetryWait(time.Millisecond, 5*time.Millisecond), WithRequestMutator(func(req *http.Request) error {
+		req.Header.Add("X-Trace-Id", "trace")
+
+		return nil
+	}))
+
+	var requests int
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if got := r.Header.Values("X-Trace-Id"); len(got) != 1 {
+			t.Errorf("request %d: X-Trace-Id = %q; want a single value", requests, got)
+		}
+
+		if requests <= 2 {
+			fmt.Fprint(w, `{"status":601,"body":{}}`)
+
+			return
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	req, err := client.NewFormRequest(context.Background(), "measure", url.Values{"action": {"getmeas"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Do(req, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 3 {
+		t.Errorf("requests = %d; want 3", requests)
+	}
+
+	if got := req.Header.Get("X-Trace-Id"); got != "" {
+		t.Errorf("caller's request was modified: X-Trace-Id = %q", got)
+	}
+}
+
+func TestClient_Do_RetryDeadline(t *testing.T) {
+	client, mux := setup(t, WithMaxRetries(1), WithRetryWait(time.Hour, 0))
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":601,"body":{}}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	start := time.Now()
+
+	_, err := client.PostForm(ctx, "measure", url.Values{"action": {"getmeas"}}, nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("error = %v; want %v", err, ErrRateLimited)
+	}
+
+	if time.Since(start) > 10*time.Second {
+		t.Error("client should not wait for a retry beyond the context deadline")
+	}
+}
+
+func TestResponse_RateLimit(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Next-Request-After", "30")
+
+		fmt.Fprint(w, `{"status":601,"body":{}}`)
+	})
+
+	resp, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"getmeas"}}, nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("error = %v; want %v", err, ErrRateLimited)
+	}
+
+	if !resp.RateLimited {
+		t.Error("response should be rate limited")
+	}
+
+	if got, want := resp.RetryAfter, 30*time.Second; got != want {
+		t.Errorf("RetryAfter = %s; want %s", got, want)
+	}
+}
+
+func TestClient_Do_RequestTimeout(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+
+	t.Run("ClientTimeout", func(t *testing.T) {
+		client, mux := setup(t, WithRequestTimeout(10*time.Millisecond))
+
+		mux.HandleFunc("/measure", handler)
+
+		_, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"getmeas"}}, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("error = %v; want %v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("EarlierContextDeadline", func(t *testing.T) {
+		client, mux := setup(t, WithRequestTimeout(time.Hour))
+
+		mux.HandleFunc("/measure", handler)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+
+		_, err := client.PostForm(ctx, "measure", url.Values{"action": {"getmeas"}}, nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("error = %v; want %v", err, context.DeadlineExceeded)
+		}
+
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("request took %s; context deadline should be respected", elapsed)
+		}
+	})
+}
+
+func TestRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"NoError", nil, false},
+		{"RateLimited", &ErrorResponse{Status: StatusTooManyRequests}, true},
+		{"UpstreamTimeout", &ErrorResponse{Status: StatusTimeout}, true},
+		{"InvalidToken", &ErrorResponse{Status: StatusInvalidToken}, false},
+		{"InvalidParams", &ErrorResponse{Status: StatusInvalidParams}, false},
+		{"Decode", &DecodeError{Err: errors.New("invalid")}, false},
+		{"Dial", &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, true},
+		{"Read", &url.Error{Op: "Post", URL: "https://example.com", Err: &net.OpError{Op: "read", Err: errors.New("connection reset by peer")}}, false},
+		{"EOF", &url.Error{Op: "Post", URL: "https://example.com", Err: io.EOF}, false},
+		{"Canceled", context.Canceled, false},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			if got := RetryableError(test.err, nil); got != test.want {
+				t.Errorf("RetryableError() = %t; want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestClient_Do_CheckRetry(t *testing.T) {
+	client, mux := setup(t, WithMaxRetries(2), WithRetryWait(time.Millisecond, 0), WithCheckRetry(func(err error, resp *Response) bool {
+		return IsTokenExpired(err)
+	}))
+
+	var requests int
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		fmt.Fprint(w, `{"status":401,"body":{}}`)
+	})
+
+	_, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"getmeas"}}, nil)
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("error = %v; want %v", err, ErrInvalidToken)
+	}
+
+	if requests != 3 {
+		t.Errorf("requests = %d; want 3", requests)
+	}
+}
+
+func TestClient_Do_NoReplayAfterResponseFailure(t *testing.T) {
+	client, mux := setup(t, WithMaxRetries(2), WithRetryWait(time.Millisecond, 0))
+
+	var requests int32
+
+	mux.HandleFunc("/notify", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		// The request reached the server, but the connection breaks before a response is sent
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+
+			return
+		}
+
+		conn.Close()
+	})
+
+	_, err := client.Notify.Subscribe(context.Background(), "https://example.com/callback", NotifyAppliWeight, "")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("requests = %d; want 1 (non-idempotent requests should not be replayed)", got)
+	}
+}
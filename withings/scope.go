@@ -0,0 +1,62 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Scopes required by the different parts of the Withings API.
+//
+// Withings API docs: https://developer.withings.com/developer-guide/v3/data-api/all-available-health-data
+const (
+	scopeUserInfo     = "user.info"
+	scopeUserMetrics  = "user.metrics"
+	scopeUserActivity = "user.activity"
+)
+
+// ErrMissingScope is returned (wrapped) by service methods
+// when the granted scopes are known and the scope required by the method is not among them.
+var ErrMissingScope = errors.New("missing scope")
+
+// WithScopes sets the scopes granted by the user (eg. from the token).
+//
+// When the granted scopes are known, service methods requiring a scope that was not granted
+// return ErrMissingScope before sending the request,
+// instead of failing with an opaque status returned by the Withings API.
+// Without granted scopes, requests are sent as usual.
+func WithScopes(scopes ...string) ClientOption {
+	return func(c *Client) {
+		c.scopes = scopes
+	}
+}
+
+type scopesContextKey struct{}
+
+// ContextWithScopes returns a copy of ctx that checks requests made with it
+// against scopes instead of the scopes set by WithScopes.
+//
+// This is useful when a single Client is shared for multiple users.
+func ContextWithScopes(ctx context.Context, scopes ...string) context.Context {
+	return context.WithValue(ctx, scopesContextKey{}, scopes)
+}
+
+// requireScope checks that scope is granted (if the granted scopes are known).
+func (c *Client) requireScope(ctx context.Context, scope string) error {
+	scopes, ok := ctx.Value(scopesContextKey{}).([]string)
+	if !ok {
+		scopes = c.scopes
+	}
+
+	if scopes == nil {
+		return nil
+	}
+
+	for _, s := range scopes {
+		if s == scope {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrMissingScope, scope)
+}
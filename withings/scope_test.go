@@ -0,0 +1,48 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClient_RequireScope(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	WithScopes("user.info", "user.activity")(client)
+
+	t.Run("Missing", func(t *testing.T) {
+		_, _, err := client.Measure.Getmeas(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{})
+		if !errors.Is(err, ErrMissingScope) {
+			t.Errorf("error = %v; want %v", err, ErrMissingScope)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		ctx := ContextWithScopes(context.Background(), "user.metrics")
+
+		_, _, err := client.Measure.Getmeas(ctx, AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		client, mux := setup(t)
+
+		mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"status":0,"body":{}}`)
+		})
+
+		_, _, err := client.Measure.Getmeas(context.Background(), AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
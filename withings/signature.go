@@ -0,0 +1,90 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sagikazarmark/go-withings/internal/signature"
+)
+
+// Signer signs requests sent to signature protected actions of the Withings API
+// using HMAC-SHA256 and the client secret.
+//
+// Withings API docs: https://developer.withings.com/developer-guide/v3/get-access/sign-your-requests
+type Signer struct {
+	ClientID     string
+	ClientSecret string
+}
+
+// NewSigner returns a new Signer for the given client credentials.
+func NewSigner(clientID string, clientSecret string) *Signer {
+	return &Signer{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}
+}
+
+// Signature computes the signature of form.
+//
+// The signature is the hex encoded HMAC-SHA256 hash (keyed with the client secret)
+// of the comma separated values of the action, client_id, nonce and timestamp fields
+// sorted by field name.
+func (s *Signer) Signature(form url.Values) string {
+	return signature.Compute(s.ClientSecret, form)
+}
+
+// Sign sets the client_id and signature fields of form.
+func (s *Signer) Sign(form url.Values) {
+	form.Set("client_id", s.ClientID)
+	form.Set("signature", s.Signature(form))
+}
+
+// WithSigner sets the Signer used for signature protected actions.
+func WithSigner(signer *Signer) ClientOption {
+	return func(c *Client) {
+		c.signer = signer
+	}
+}
+
+// errSignerNotConfigured is returned by signature protected actions
+// when the Client has no Signer.
+var errSignerNotConfigured = errors.New("signer not configured; use withings.WithSigner")
+
+type getnonceResponse struct {
+	Body struct {
+		Nonce string `json:"nonce"`
+	} `json:"body"`
+}
+
+// Nonce requests a nonce that can be used (once) to sign a request.
+//
+// The Client must be configured with a Signer.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/signaturev2-getnonce
+func (c *Client) Nonce(ctx context.Context) (string, *Response, error) {
+	if c == nil {
+		return "", nil, errClientNotInitialized
+	}
+
+	if c.signer == nil {
+		return "", nil, errSignerNotConfigured
+	}
+
+	const urlPath = "v2/signature"
+
+	form := url.Values{
+		"action":    {"getnonce"},
+		"timestamp": {strconv.FormatInt(time.Now().Unix(), 10)},
+	}
+
+	c.signer.Sign(form)
+
+	nonceResp := new(getnonceResponse)
+
+	resp, err := c.PostForm(ctx, urlPath, form, nonceResp)
+
+	return nonceResp.Body.Nonce, resp, err
+}
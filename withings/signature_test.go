@@ -0,0 +1,72 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestSigner_Signature(t *testing.T) {
+	signer := NewSigner("client", "secret")
+
+	form := url.Values{
+		"timestamp": {"1234567890"},
+		"action":    {"getnonce"},
+		"client_id": {"client"},
+		"other":     {"ignored"},
+	}
+
+	// echo -n "getnonce,client,1234567890" | openssl dgst -sha256 -hmac secret
+	const want = "df3dc4f62d7c98581c74a5b847e63cb73dfb5baf3dd0c57c0ca6162bf0823c9e"
+
+	if got := signer.Signature(form); got != want {
+		t.Errorf("signature = %q; want %q", got, want)
+	}
+}
+
+func TestClient_Nonce(t *testing.T) {
+	signer := NewSigner("client", "secret")
+
+	client, mux := setup(t, WithSigner(signer))
+
+	mux.HandleFunc("/v2/signature", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("action"), "getnonce"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		if got, want := r.FormValue("client_id"), "client"; got != want {
+			t.Errorf("client_id = %q; want %q", got, want)
+		}
+
+		if r.FormValue("timestamp") == "" {
+			t.Error("timestamp is empty")
+		}
+
+		if got, want := r.FormValue("signature"), signer.Signature(r.PostForm); got != want {
+			t.Errorf("signature = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{"nonce":"abc"}}`)
+	})
+
+	nonce, _, err := client.Nonce(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := nonce, "abc"; got != want {
+		t.Errorf("nonce = %q; want %q", got, want)
+	}
+}
+
+func TestClient_Nonce_NoSigner(t *testing.T) {
+	client, _ := setup(t)
+
+	_, _, err := client.Nonce(context.Background())
+	if !errors.Is(err, errSignerNotConfigured) {
+		t.Errorf("error = %v; want %v", err, errSignerNotConfigured)
+	}
+}
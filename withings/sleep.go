@@ -1,7 +1,627 @@
 package withings
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
 // SleepService handles communication with the sleep related
 // methods of the Withings API.
 //
 // Withings API docs: https://developer.withings.com/api-reference/#tag/sleep
 type SleepService service
+
+// SleepField is a type of metric tracked during sleep.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/sleepv2-get
+type SleepField string
+
+// SleepField values
+const (
+	SleepFieldHR                SleepField = "hr"                  // Heart Rate.
+	SleepFieldRR                SleepField = "rr"                  // Respiration Rate.
+	SleepFieldSnoring           SleepField = "snoring"             // Total snoring time.
+	SleepFieldSDNN1             SleepField = "sdnn_1"              // Heart rate variability - Standard deviation of the NN over 1 minute.
+	SleepFieldRMSSD             SleepField = "rmssd"               // Heart rate variability - Root mean square of the successive differences over "a few seconds".
+	SleepFieldHRVQuality        SleepField = "hrv_quality"         // Heart rate variability - Quality score.
+	SleepFieldMovementScore     SleepField = "mvt_score"           // Track the intensity of movement in bed on a minute-by-minute basis.
+	SleepFieldChestMovementRate SleepField = "chest_movement_rate" // Chest movement rate.
+	SleepFieldWithingsIndex     SleepField = "withings_index"      // Withings index.
+	SleepFieldBreathingSounds   SleepField = "breathing_sounds"    // Breathing sounds.
+)
+
+var validSleepFieldValues = map[SleepField]struct{}{
+	SleepFieldHR:                {},
+	SleepFieldRR:                {},
+	SleepFieldSnoring:           {},
+	SleepFieldSDNN1:             {},
+	SleepFieldRMSSD:             {},
+	SleepFieldHRVQuality:        {},
+	SleepFieldMovementScore:     {},
+	SleepFieldChestMovementRate: {},
+	SleepFieldWithingsIndex:     {},
+	SleepFieldBreathingSounds:   {},
+}
+
+// IsValid checks if v is a valid SleepField.
+func (v SleepField) IsValid() bool {
+	_, ok := validSleepFieldValues[v]
+
+	return ok
+}
+
+// AllSleepFields is the list of all SleepField values.
+func AllSleepFields() []SleepField {
+	return []SleepField{
+		SleepFieldHR,
+		SleepFieldRR,
+		SleepFieldSnoring,
+		SleepFieldSDNN1,
+		SleepFieldRMSSD,
+		SleepFieldHRVQuality,
+		SleepFieldMovementScore,
+		SleepFieldChestMovementRate,
+		SleepFieldWithingsIndex,
+		SleepFieldBreathingSounds,
+	}
+}
+
+// SleepState is the state of the user during a sleep segment.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/sleepv2-get
+type SleepState int
+
+// SleepState values
+const (
+	SleepStateAwake       SleepState = 0 // Awake.
+	SleepStateLight       SleepState = 1 // Light sleep.
+	SleepStateDeep        SleepState = 2 // Deep sleep.
+	SleepStateREM         SleepState = 3 // REM sleep.
+	SleepStateManual      SleepState = 4 // Manually entered sleep.
+	SleepStateUnspecified SleepState = 5 // Unspecified (eg. sleep detected by a tracker without stage information).
+)
+
+var sleepStateNames = map[SleepState]string{
+	SleepStateAwake:       "Awake",
+	SleepStateLight:       "Light",
+	SleepStateDeep:        "Deep",
+	SleepStateREM:         "REM",
+	SleepStateManual:      "Manual",
+	SleepStateUnspecified: "Unspecified",
+}
+
+// IsValid checks if v is a valid SleepState.
+func (v SleepState) IsValid() bool {
+	_, ok := sleepStateNames[v]
+
+	return ok
+}
+
+// String returns the human readable name of v.
+func (v SleepState) String() string {
+	if name, ok := sleepStateNames[v]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("SleepState(%d)", int(v))
+}
+
+// IsAsleep reports whether v is a sleep stage (light, deep or REM sleep).
+func (v SleepState) IsAsleep() bool {
+	return v == SleepStateLight || v == SleepStateDeep || v == SleepStateREM
+}
+
+type sleepGetResponse struct {
+	Body Sleep `json:"body"`
+}
+
+// Sleep is the response from the Get API call.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/sleepv2-get
+type Sleep struct {
+	Series []SleepSegment `json:"series"`
+}
+
+// SleepSegment is a period of time spent in the same sleep state.
+//
+// Series fields are populated based on the requested fields.
+// They are keyed by unix timestamps.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/sleepv2-get
+type SleepSegment struct {
+	StartDate int64       `json:"startdate"`
+	EndDate   int64       `json:"enddate"`
+	State     SleepState  `json:"state"`
+	Model     int         `json:"model"`
+	ModelID   DeviceModel `json:"model_id"`
+
+	// Fields
+	HR                map[string]int     `json:"hr"`
+	RR                map[string]int     `json:"rr"`
+	Snoring           map[string]int     `json:"snoring"`
+	SDNN1             map[string]float64 `json:"sdnn_1"`
+	RMSSD             map[string]float64 `json:"rmssd"`
+	HRVQuality        map[string]int     `json:"hrv_quality"`
+	MovementScore     map[string]int     `json:"mvt_score"`
+	ChestMovementRate map[string]int     `json:"chest_movement_rate"`
+	WithingsIndex     map[string]int     `json:"withings_index"`
+	BreathingSounds   map[string]int     `json:"breathing_sounds"`
+}
+
+// Get returns sleep data captured at high frequency, including sleep stages.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/sleepv2-get
+func (s *SleepService) Get(ctx context.Context, fields []SleepField, opts MeasureGetOptions) (*Sleep, *Response, error) {
+	if s == nil || s.client == nil {
+		return nil, nil, errClientNotInitialized
+	}
+
+	if err := s.client.requireScope(ctx, scopeUserActivity); err != nil {
+		return nil, nil, err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	fields = filterValidSleepFieldValues(fields)
+
+	if len(fields) == 0 {
+		return nil, nil, errors.New("need at least one sleep data field")
+	}
+
+	const urlPath = "v2/sleep"
+
+	form := url.Values{
+		"action":      {"get"},
+		"data_fields": {joinSleepFields(fields)},
+	}
+
+	DateRange{Start: opts.StartDate, End: opts.EndDate}.EncodeValues(form, DateModeUnix)
+
+	sleepResp := new(sleepGetResponse)
+
+	resp, err := s.client.PostForm(ctx, urlPath, form, sleepResp)
+
+	return &sleepResp.Body, resp, err
+}
+
+func filterValidSleepFieldValues(values []SleepField) []SleepField {
+	var validValues []SleepField
+
+	for _, v := range values {
+		if !v.IsValid() {
+			continue
+		}
+
+		validValues = append(validValues, v)
+	}
+
+	return validValues
+}
+
+func joinSleepFields(fields []SleepField) string {
+	s := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		s = append(s, string(f))
+	}
+
+	return strings.Join(s, ",")
+}
+
+// SleepSummaryField is a type of metric aggregated for a sleep session.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/sleepv2-getsummary
+type SleepSummaryField string
+
+// SleepSummaryField values
+const (
+	SleepSummaryFieldNbREMEpisodes                  SleepSummaryField = "nb_rem_episodes"                  // Count of the REM sleep phases.
+	SleepSummaryFieldSleepEfficiency                SleepSummaryField = "sleep_efficiency"                 // Ratio of the total sleep time over the time spent in bed.
+	SleepSummaryFieldSleepLatency                   SleepSummaryField = "sleep_latency"                    // Time spent in bed before falling asleep (in seconds).
+	SleepSummaryFieldTotalSleepTime                 SleepSummaryField = "total_sleep_time"                 // Total time spent asleep (in seconds).
+	SleepSummaryFieldTotalTimeInBed                 SleepSummaryField = "total_timeinbed"                  // Total time spent in bed (in seconds).
+	SleepSummaryFieldWakeupLatency                  SleepSummaryField = "wakeup_latency"                   // Time spent in bed after waking up (in seconds).
+	SleepSummaryFieldWASO                           SleepSummaryField = "waso"                             // Time awake in bed after falling asleep for the first time (in seconds).
+	SleepSummaryFieldApneaHypopneaIndex             SleepSummaryField = "apnea_hypopnea_index"             // Medical grade AHI.
+	SleepSummaryFieldBreathingDisturbancesIntensity SleepSummaryField = "breathing_disturbances_intensity" // Wellness metric, available for all Sleep and Sleep Analyzer devices.
+	SleepSummaryFieldAsleepDuration                 SleepSummaryField = "asleepduration"                   // Duration of sleep when night comes from external source (in seconds).
+	SleepSummaryFieldDeepSleepDuration              SleepSummaryField = "deepsleepduration"                // Duration in state deep sleep (in seconds).
+	SleepSummaryFieldDurationToSleep                SleepSummaryField = "durationtosleep"                  // Time to sleep (in seconds).
+	SleepSummaryFieldDurationToWakeup               SleepSummaryField = "durationtowakeup"                 // Time to wake up (in seconds).
+	SleepSummaryFieldHRAverage                      SleepSummaryField = "hr_average"                       // Average heart rate.
+	SleepSummaryFieldHRMax                          SleepSummaryField = "hr_max"                           // Maximal heart rate.
+	SleepSummaryFieldHRMin                          SleepSummaryField = "hr_min"                           // Minimal heart rate.
+	SleepSummaryFieldLightSleepDuration             SleepSummaryField = "lightsleepduration"               // Duration in state light sleep (in seconds).
+	SleepSummaryFieldNightEvents                    SleepSummaryField = "night_events"                     // Events that happened during the night.
+	SleepSummaryFieldOutOfBedCount                  SleepSummaryField = "out_of_bed_count"                 // Number of times the user got out of bed during the night.
+	SleepSummaryFieldREMSleepDuration               SleepSummaryField = "remsleepduration"                 // Duration in state REM sleep (in seconds).
+	SleepSummaryFieldRRAverage                      SleepSummaryField = "rr_average"                       // Average respiration rate.
+	SleepSummaryFieldRRMax                          SleepSummaryField = "rr_max"                           // Maximal respiration rate.
+	SleepSummaryFieldRRMin                          SleepSummaryField = "rr_min"                           // Minimal respiration rate.
+	SleepSummaryFieldSleepScore                     SleepSummaryField = "sleep_score"                      // Sleep score.
+	SleepSummaryFieldSnoring                        SleepSummaryField = "snoring"                          // Total snoring time.
+	SleepSummaryFieldSnoringEpisodeCount            SleepSummaryField = "snoringepisodecount"              // Numbers of snoring episodes of at least one minute.
+	SleepSummaryFieldWakeupCount                    SleepSummaryField = "wakeupcount"                      // Number of times the user woke up.
+	SleepSummaryFieldWakeupDuration                 SleepSummaryField = "wakeupduration"                   // Time spent awake (in seconds).
+)
+
+var validSleepSummaryFieldValues = map[SleepSummaryField]struct{}{
+	SleepSummaryFieldNbREMEpisodes:                  {},
+	SleepSummaryFieldSleepEfficiency:                {},
+	SleepSummaryFieldSleepLatency:                   {},
+	SleepSummaryFieldTotalSleepTime:                 {},
+	SleepSummaryFieldTotalTimeInBed:                 {},
+	SleepSummaryFieldWakeupLatency:                  {},
+	SleepSummaryFieldWASO:                           {},
+	SleepSummaryFieldApneaHypopneaIndex:             {},
+	SleepSummaryFieldBreathingDisturbancesIntensity: {},
+	SleepSummaryFieldAsleepDuration:                 {},
+	SleepSummaryFieldDeepSleepDuration:              {},
+	SleepSummaryFieldDurationToSleep:                {},
+	SleepSummaryFieldDurationToWakeup:               {},
+	SleepSummaryFieldHRAverage:                      {},
+	SleepSummaryFieldHRMax:                          {},
+	SleepSummaryFieldHRMin:                          {},
+	SleepSummaryFieldLightSleepDuration:             {},
+	SleepSummaryFieldNightEvents:                    {},
+	SleepSummaryFieldOutOfBedCount:                  {},
+	SleepSummaryFieldREMSleepDuration:               {},
+	SleepSummaryFieldRRAverage:                      {},
+	SleepSummaryFieldRRMax:                          {},
+	SleepSummaryFieldRRMin:                          {},
+	SleepSummaryFieldSleepScore:                     {},
+	SleepSummaryFieldSnoring:                        {},
+	SleepSummaryFieldSnoringEpisodeCount:            {},
+	SleepSummaryFieldWakeupCount:                    {},
+	SleepSummaryFieldWakeupDuration:                 {},
+}
+
+// IsValid checks if v is a valid SleepSummaryField.
+func (v SleepSummaryField) IsValid() bool {
+	_, ok := validSleepSummaryFieldValues[v]
+
+	return ok
+}
+
+// AllSleepSummaryFields is the list of all SleepSummaryField values.
+func AllSleepSummaryFields() []SleepSummaryField {
+	return []SleepSummaryField{
+		SleepSummaryFieldNbREMEpisodes,
+		SleepSummaryFieldSleepEfficiency,
+		SleepSummaryFieldSleepLatency,
+		SleepSummaryFieldTotalSleepTime,
+		SleepSummaryFieldTotalTimeInBed,
+		SleepSummaryFieldWakeupLatency,
+		SleepSummaryFieldWASO,
+		SleepSummaryFieldApneaHypopneaIndex,
+		SleepSummaryFieldBreathingDisturbancesIntensity,
+		SleepSummaryFieldAsleepDuration,
+		SleepSummaryFieldDeepSleepDuration,
+		SleepSummaryFieldDurationToSleep,
+		SleepSummaryFieldDurationToWakeup,
+		SleepSummaryFieldHRAverage,
+		SleepSummaryFieldHRMax,
+		SleepSummaryFieldHRMin,
+		SleepSummaryFieldLightSleepDuration,
+		SleepSummaryFieldNightEvents,
+		SleepSummaryFieldOutOfBedCount,
+		SleepSummaryFieldREMSleepDuration,
+		SleepSummaryFieldRRAverage,
+		SleepSummaryFieldRRMax,
+		SleepSummaryFieldRRMin,
+		SleepSummaryFieldSleepScore,
+		SleepSummaryFieldSnoring,
+		SleepSummaryFieldSnoringEpisodeCount,
+		SleepSummaryFieldWakeupCount,
+		SleepSummaryFieldWakeupDuration,
+	}
+}
+
+type sleepGetSummaryResponse struct {
+	Body SleepSummaries `json:"body"`
+}
+
+// SleepSummaries is the response from the GetSummary API call.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/sleepv2-getsummary
+type SleepSummaries struct {
+	Series []SleepSummary `json:"series"`
+}
+
+// SleepSummary aggregates metrics of a single sleep session.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/sleepv2-getsummary
+type SleepSummary struct {
+	ID        int64       `json:"id"`
+	Timezone  string      `json:"timezone"`
+	Model     int         `json:"model"`
+	ModelID   DeviceModel `json:"model_id"`
+	StartDate int64       `json:"startdate"`
+	EndDate   int64       `json:"enddate"`
+	Date      string      `json:"date"`
+	Created   int64       `json:"created"`
+	Modified  int64       `json:"modified"`
+
+	Data SleepSummaryData `json:"data"`
+}
+
+// SleepSummaryData contains the metrics of a sleep session.
+//
+// Fields are populated based on the requested fields.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/sleepv2-getsummary
+type SleepSummaryData struct {
+	NbREMEpisodes                  int         `json:"nb_rem_episodes"`
+	SleepEfficiency                float64     `json:"sleep_efficiency"`
+	SleepLatency                   int         `json:"sleep_latency"`
+	TotalSleepTime                 int         `json:"total_sleep_time"`
+	TotalTimeInBed                 int         `json:"total_timeinbed"`
+	WakeupLatency                  int         `json:"wakeup_latency"`
+	WASO                           int         `json:"waso"`
+	ApneaHypopneaIndex             int         `json:"apnea_hypopnea_index"`
+	BreathingDisturbancesIntensity int         `json:"breathing_disturbances_intensity"`
+	AsleepDuration                 int         `json:"asleepduration"`
+	DeepSleepDuration              int         `json:"deepsleepduration"`
+	DurationToSleep                int         `json:"durationtosleep"`
+	DurationToWakeup               int         `json:"durationtowakeup"`
+	HRAverage                      int         `json:"hr_average"`
+	HRMax                          int         `json:"hr_max"`
+	HRMin                          int         `json:"hr_min"`
+	LightSleepDuration             int         `json:"lightsleepduration"`
+	NightEvents                    interface{} `json:"night_events"` // Use SleepSummary.NightEvents to access typed events.
+	OutOfBedCount                  int         `json:"out_of_bed_count"`
+	REMSleepDuration               int         `json:"remsleepduration"`
+	RRAverage                      int         `json:"rr_average"`
+	RRMax                          int         `json:"rr_max"`
+	RRMin                          int         `json:"rr_min"`
+	SleepScore                     int         `json:"sleep_score"`
+	Snoring                        int         `json:"snoring"`
+	SnoringEpisodeCount            int         `json:"snoringepisodecount"`
+	WakeupCount                    int         `json:"wakeupcount"`
+	WakeupDuration                 int         `json:"wakeupduration"`
+}
+
+// NightEventType is the type of an event that happened during the night.
+//
+// Codes 1-6 are instant events: they are reported as a single offset, so their Start and End are the same.
+// Codes 7 (snoring) and 8 (apnea) are episodes: they are reported as [start, end] offset pairs,
+// so they can be overlaid on the hypnogram.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/sleepv2-getsummary
+type NightEventType int
+
+// NightEventType values
+const (
+	NightEventTypeGotInBed         NightEventType = 1 // Got in bed.
+	NightEventTypeFellAsleep       NightEventType = 2 // Fell asleep.
+	NightEventTypeWokeUp           NightEventType = 3 // Woke up.
+	NightEventTypeGotOutOfBed      NightEventType = 4 // Got out of bed.
+	NightEventTypeManualSleepStart NightEventType = 5 // Sleep start manually entered by the user.
+	NightEventTypeManualSleepEnd   NightEventType = 6 // Sleep end manually entered by the user.
+	NightEventTypeSnoring          NightEventType = 7 // Snoring episode.
+	NightEventTypeApnea            NightEventType = 8 // Apnea (breathing disturbance) episode.
+)
+
+var nightEventTypeNames = map[NightEventType]string{
+	NightEventTypeGotInBed:         "Got In Bed",
+	NightEventTypeFellAsleep:       "Fell Asleep",
+	NightEventTypeWokeUp:           "Woke Up",
+	NightEventTypeGotOutOfBed:      "Got Out Of Bed",
+	NightEventTypeManualSleepStart: "Manual Sleep Start",
+	NightEventTypeManualSleepEnd:   "Manual Sleep End",
+	NightEventTypeSnoring:          "Snoring",
+	NightEventTypeApnea:            "Apnea",
+}
+
+// IsValid checks if v is a valid NightEventType.
+func (v NightEventType) IsValid() bool {
+	_, ok := nightEventTypeNames[v]
+
+	return ok
+}
+
+// String returns the human readable name of v.
+func (v NightEventType) String() string {
+	if name, ok := nightEventTypeNames[v]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("NightEventType(%d)", int(v))
+}
+
+// NightEvent is an event that happened during the night.
+//
+// Start and End are the same for instant events (see NightEventType).
+type NightEvent struct {
+	Type  NightEventType
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the event (zero for instant events).
+func (e NightEvent) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
+
+// Efficiency returns the ratio (0-1) of the time spent asleep and the time spent in bed.
+//
+// It requires the total_sleep_time and total_timeinbed fields.
+// If they are missing, the sleep_efficiency field computed by Withings is returned (which is zero if it was not requested either).
+func (s SleepSummary) Efficiency() float64 {
+	if s.Data.TotalTimeInBed <= 0 {
+		return s.Data.SleepEfficiency
+	}
+
+	return float64(s.Data.TotalSleepTime) / float64(s.Data.TotalTimeInBed)
+}
+
+// TimeToSleep returns the time it took the user to fall asleep.
+//
+// It requires the durationtosleep (or sleep_latency) field.
+func (s SleepSummary) TimeToSleep() time.Duration {
+	if s.Data.DurationToSleep > 0 {
+		return time.Duration(s.Data.DurationToSleep) * time.Second
+	}
+
+	return time.Duration(s.Data.SleepLatency) * time.Second
+}
+
+// WASO returns the time spent awake after falling asleep for the first time (Wake After Sleep Onset).
+//
+// It requires the waso field.
+func (s SleepSummary) WASO() time.Duration {
+	return time.Duration(s.Data.WASO) * time.Second
+}
+
+// NightEvents returns the events that happened during the night in chronological order.
+//
+// The raw night_events field maps event types to a list of offsets (in seconds) relative to the start of the sleep session.
+// Each item of the list is either a single offset (instant events) or a [start, end] pair of offsets (episodes).
+// NightEvents returns nil if the field was not requested or no events happened.
+func (s SleepSummary) NightEvents() ([]NightEvent, error) {
+	rawEvents, ok := s.Data.NightEvents.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	var events []NightEvent
+
+	for key, rawOffsets := range rawEvents {
+		eventType, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid night event type %q: %w", key, err)
+		}
+
+		var items []interface{}
+
+		if err := decode(rawOffsets, &items); err != nil {
+			return nil, fmt.Errorf("invalid night event offsets for type %q: %w", key, err)
+		}
+
+		for _, item := range items {
+			start, end, err := parseNightEventOffsets(item)
+			if err != nil {
+				return nil, fmt.Errorf("invalid night event offsets for type %q: %w", key, err)
+			}
+
+			events = append(events, NightEvent{
+				Type:  NightEventType(eventType),
+				Start: time.Unix(s.StartDate+start, 0),
+				End:   time.Unix(s.StartDate+end, 0),
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Start.Equal(events[j].Start) {
+			return events[i].Type < events[j].Type
+		}
+
+		return events[i].Start.Before(events[j].Start)
+	})
+
+	return events, nil
+}
+
+// parseNightEventOffsets parses a single offset or a [start, end] pair of offsets.
+func parseNightEventOffsets(item interface{}) (int64, int64, error) {
+	if _, ok := item.([]interface{}); !ok {
+		var offset int64
+
+		if err := decode(item, &offset); err != nil {
+			return 0, 0, err
+		}
+
+		return offset, offset, nil
+	}
+
+	var pair []int64
+
+	if err := decode(item, &pair); err != nil {
+		return 0, 0, err
+	}
+
+	if len(pair) != 2 {
+		return 0, 0, fmt.Errorf("expected a [start, end] pair, got %d offsets", len(pair))
+	}
+
+	if pair[1] < pair[0] {
+		return 0, 0, fmt.Errorf("end offset %d is before start offset %d", pair[1], pair[0])
+	}
+
+	return pair[0], pair[1], nil
+}
+
+// GetSummary returns sleep activity summaries, which are an aggregation of all the data captured at high frequency during the sleep activity.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/sleepv2-getsummary
+func (s *SleepService) GetSummary(ctx context.Context, fields []SleepSummaryField, opts ActivityGetOptions) (*SleepSummaries, *Response, error) {
+	if s == nil || s.client == nil {
+		return nil, nil, errClientNotInitialized
+	}
+
+	if err := s.client.requireScope(ctx, scopeUserActivity); err != nil {
+		return nil, nil, err
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	fields = filterValidSleepSummaryFieldValues(fields)
+
+	if len(fields) == 0 {
+		return nil, nil, errors.New("need at least one sleep summary data field")
+	}
+
+	const urlPath = "v2/sleep"
+
+	form := url.Values{
+		"action":      {"getsummary"},
+		"data_fields": {joinSleepSummaryFields(fields)},
+	}
+
+	opts.EncodeValues(form, DateModeYMD)
+
+	if opts.Offset > 0 {
+		form.Add("offset", fmt.Sprintf("%d", opts.Offset))
+	}
+
+	summaryResp := new(sleepGetSummaryResponse)
+
+	resp, err := s.client.PostForm(ctx, urlPath, form, summaryResp)
+
+	return &summaryResp.Body, resp, err
+}
+
+func filterValidSleepSummaryFieldValues(values []SleepSummaryField) []SleepSummaryField {
+	var validValues []SleepSummaryField
+
+	for _, v := range values {
+		if !v.IsValid() {
+			continue
+		}
+
+		validValues = append(validValues, v)
+	}
+
+	return validValues
+}
+
+func joinSleepSummaryFields(fields []SleepSummaryField) string {
+	s := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+		s = append(s, string(f))
+	}
+
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,256 @@
+package withings
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSleepField(t *testing.T) {
+	t.Run("AllValid", func(t *testing.T) {
+		for _, v := range AllSleepFields() {
+			if !v.IsValid() {
+				t.Errorf("%s is supposed to be a valid SleepField", v)
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if SleepField("invalid").IsValid() {
+			t.Error("non existent SleepField should not be valid")
+		}
+	})
+}
+
+func TestSleepSummaryField(t *testing.T) {
+	t.Run("AllValid", func(t *testing.T) {
+		for _, v := range AllSleepSummaryFields() {
+			if !v.IsValid() {
+				t.Errorf("%s is supposed to be a valid SleepSummaryField", v)
+			}
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		if SleepSummaryField("invalid").IsValid() {
+			t.Error("non existent SleepSummaryField should not be valid")
+		}
+	})
+}
+
+func TestSleepService_Get(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v2/sleep", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("action"), "get"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		if got, want := r.FormValue("data_fields"), "hr,rr"; got != want {
+			t.Errorf("data_fields = %q; want %q", got, want)
+		}
+
+		if got, want := r.FormValue("startdate"), "1640995200"; got != want {
+			t.Errorf("startdate = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{"series":[{"startdate":1640995200,"enddate":1640998800,"state":2,"model_id":63,"hr":{"1640995200":55},"rr":{"1640995200":14}}]}}`)
+	})
+
+	sleep, _, err := client.Sleep.Get(context.Background(), []SleepField{SleepFieldHR, SleepFieldRR, SleepField("invalid")}, MeasureGetOptions{
+		StartDate: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2022, time.January, 2, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sleep.Series) != 1 {
+		t.Fatalf("got %d sleep segments; want 1", len(sleep.Series))
+	}
+
+	segment := sleep.Series[0]
+
+	if segment.State != SleepStateDeep {
+		t.Errorf("State = %v; want %v", segment.State, SleepStateDeep)
+	}
+
+	if segment.ModelID != DeviceModelSleepAnalyzer {
+		t.Errorf("ModelID = %v; want %v", segment.ModelID, DeviceModelSleepAnalyzer)
+	}
+
+	if got, want := segment.HR["1640995200"], 55; got != want {
+		t.Errorf("HR = %d; want %d", got, want)
+	}
+
+	if got, want := segment.RR["1640995200"], 14; got != want {
+		t.Errorf("RR = %d; want %d", got, want)
+	}
+
+	t.Run("NoFields", func(t *testing.T) {
+		_, _, err := client.Sleep.Get(context.Background(), []SleepField{SleepField("invalid")}, MeasureGetOptions{})
+		if err == nil {
+			t.Error("Get should fail without any valid field")
+		}
+	})
+}
+
+func TestSleepService_GetSummary(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v2/sleep", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("action"), "getsummary"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		if got, want := r.FormValue("startdateymd"), "2022-01-01"; got != want {
+			t.Errorf("startdateymd = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{"series":[{"id":1,"date":"2022-01-01","data":{"deepsleepduration":3600,"sleep_score":80,"hr_average":55}}],"more":false,"offset":0}}`)
+	})
+
+	summaries, _, err := client.Sleep.GetSummary(context.Background(), AllSleepSummaryFields(), ActivityGetOptions{
+		DateRange: DateRange{
+			Start: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2022, time.January, 2, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(summaries.Series) != 1 {
+		t.Fatalf("got %d summaries; want 1", len(summaries.Series))
+	}
+
+	data := summaries.Series[0].Data
+
+	if data.DeepSleepDuration != 3600 || data.SleepScore != 80 || data.HRAverage != 55 {
+		t.Errorf("unexpected summary data: %+v", data)
+	}
+}
+
+func TestSleepSummary_Metrics(t *testing.T) {
+	summary := SleepSummary{
+		Data: SleepSummaryData{
+			TotalSleepTime:  24000,
+			TotalTimeInBed:  30000,
+			SleepEfficiency: 0.75,
+			DurationToSleep: 600,
+			SleepLatency:    900,
+			WASO:            1200,
+		},
+	}
+
+	if got, want := summary.Efficiency(), 0.8; got != want {
+		t.Errorf("Efficiency() = %v; want %v", got, want)
+	}
+
+	if got, want := summary.TimeToSleep(), 10*time.Minute; got != want {
+		t.Errorf("TimeToSleep() = %s; want %s", got, want)
+	}
+
+	if got, want := summary.WASO(), 20*time.Minute; got != want {
+		t.Errorf("WASO() = %s; want %s", got, want)
+	}
+
+	t.Run("Fallback", func(t *testing.T) {
+		summary := SleepSummary{Data: SleepSummaryData{SleepEfficiency: 0.75, SleepLatency: 900}}
+
+		if got, want := summary.Efficiency(), 0.75; got != want {
+			t.Errorf("Efficiency() = %v; want %v", got, want)
+		}
+
+		if got, want := summary.TimeToSleep(), 15*time.Minute; got != want {
+			t.Errorf("TimeToSleep() = %s; want %s", got, want)
+		}
+	})
+}
+
+func TestSleepSummary_NightEvents(t *testing.T) {
+	summary := SleepSummary{
+		StartDate: 1641070800,
+		Data: SleepSummaryData{
+			NightEvents: map[string]interface{}{
+				"1": []interface{}{float64(0)},
+				"2": []interface{}{float64(420)},
+				"3": []interface{}{float64(120), float64(29340)},
+				"7": []interface{}{[]interface{}{float64(3600), float64(3900)}},
+			},
+		},
+	}
+
+	events, err := summary.NightEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []NightEvent{
+		{Type: NightEventTypeGotInBed, Start: time.Unix(1641070800, 0), End: time.Unix(1641070800, 0)},
+		{Type: NightEventTypeWokeUp, Start: time.Unix(1641070920, 0), End: time.Unix(1641070920, 0)},
+		{Type: NightEventTypeFellAsleep, Start: time.Unix(1641071220, 0), End: time.Unix(1641071220, 0)},
+		{Type: NightEventTypeSnoring, Start: time.Unix(1641074400, 0), End: time.Unix(1641074700, 0)},
+		{Type: NightEventTypeWokeUp, Start: time.Unix(1641100140, 0), End: time.Unix(1641100140, 0)},
+	}
+
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %+v; want %+v", events, want)
+	}
+
+	if got, want := events[3].Duration(), 5*time.Minute; got != want {
+		t.Errorf("Duration() = %s; want %s", got, want)
+	}
+
+	t.Run("InvalidEpisode", func(t *testing.T) {
+		summary := SleepSummary{
+			Data: SleepSummaryData{
+				NightEvents: map[string]interface{}{
+					"8": []interface{}{[]interface{}{float64(3900), float64(3600)}},
+				},
+			},
+		}
+
+		if _, err := summary.NightEvents(); err == nil {
+			t.Error("an episode ending before it starts should be rejected")
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		events, err := SleepSummary{Data: SleepSummaryData{NightEvents: []interface{}{}}}.NightEvents()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if events != nil {
+			t.Errorf("events = %+v; want nil", events)
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		if got, want := NightEventTypeFellAsleep.String(), "Fell Asleep"; got != want {
+			t.Errorf("String() = %q; want %q", got, want)
+		}
+	})
+}
+
+func TestSleepState(t *testing.T) {
+	if got, want := SleepStateREM.String(), "REM"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+
+	if SleepStateAwake.IsAsleep() {
+		t.Error("awake should not be asleep")
+	}
+
+	if !SleepStateDeep.IsAsleep() {
+		t.Error("deep sleep should be asleep")
+	}
+
+	if SleepState(9).IsValid() {
+		t.Error("non existent SleepState should not be valid")
+	}
+}
@@ -0,0 +1,44 @@
+package withings
+
+// Conversion factors between SI and imperial units.
+const (
+	poundsPerKilogram = 2.20462262185
+	metersPerMile     = 1609.344
+	metersPerFoot     = 0.3048
+)
+
+// KilogramsToPounds converts a mass from kilograms to pounds.
+func KilogramsToPounds(kg float64) float64 {
+	return kg * poundsPerKilogram
+}
+
+// MetersToMiles converts a distance from meters to miles.
+func MetersToMiles(m float64) float64 {
+	return m / metersPerMile
+}
+
+// MetersToFeet converts a distance from meters to feet.
+func MetersToFeet(m float64) float64 {
+	return m / metersPerFoot
+}
+
+// CelsiusToFahrenheit converts a temperature from degrees Celsius to degrees Fahrenheit.
+func CelsiusToFahrenheit(c float64) float64 {
+	return c*9/5 + 32
+}
+
+// Pounds returns the value of the measure in pounds.
+//
+// It returns zero for measure types that are not measured in kilograms.
+func (m Measure) Pounds() float64 {
+	if m.Type.Unit() != "kg" {
+		return 0
+	}
+
+	return KilogramsToPounds(m.FloatValue())
+}
+
+// DistanceMiles returns the distance travelled during the day in miles.
+func (a Activity) DistanceMiles() float64 {
+	return MetersToMiles(a.Distance)
+}
@@ -0,0 +1,32 @@
+package withings
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUnitConversions(t *testing.T) {
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"KilogramsToPounds", KilogramsToPounds(1), 2.20462},
+		{"MetersToMiles", MetersToMiles(1609.344), 1},
+		{"MetersToFeet", MetersToFeet(1), 3.28084},
+		{"CelsiusToFahrenheit", CelsiusToFahrenheit(37), 98.6},
+		{"Pounds", Measure{Value: 70000, Type: MeasureTypeWeight, Unit: -3}.Pounds(), 154.32358},
+		{"PoundsNonMass", Measure{Value: 62, Type: MeasureTypeHeartPulse}.Pounds(), 0},
+		{"DistanceMiles", Activity{Distance: 5000}.DistanceMiles(), 3.10686},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			if math.Abs(test.got-test.want) > 1e-4 {
+				t.Errorf("got %v; want %v", test.got, test.want)
+			}
+		})
+	}
+}
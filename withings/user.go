@@ -0,0 +1,296 @@
+package withings
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+)
+
+// UserService handles communication with the user related
+// methods of the Withings API.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#tag/user
+type UserService service
+
+type getdeviceResponse struct {
+	Body struct {
+		Devices []Device `json:"devices"`
+	} `json:"body"`
+}
+
+// Device is a device linked to the user's account.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/userv2-getdevice
+type Device struct {
+	Type             string       `json:"type"`
+	Model            string       `json:"model"`
+	ModelID          DeviceModel  `json:"model_id"`
+	Battery          BatteryLevel `json:"battery"`
+	DeviceID         string       `json:"deviceid"`
+	HashDeviceID     string       `json:"hash_deviceid"`
+	Timezone         string       `json:"timezone"`
+	LastSessionDate  int64        `json:"last_session_date"`
+	FirstSessionDate int64        `json:"first_session_date"`
+}
+
+// LowBattery reports whether the battery of the device is low.
+func (d Device) LowBattery() bool {
+	return d.Battery == BatteryLevelLow
+}
+
+// DeviceModel identifies the model of a Withings device.
+//
+// The list of models is not exhaustive: unknown models are represented by their numeric value.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/userv2-getdevice
+type DeviceModel int
+
+// DeviceModel values
+const (
+	DeviceModelWBS01                DeviceModel = 1    // Withings WBS01
+	DeviceModelWS30                 DeviceModel = 2    // WS30
+	DeviceModelKidScale             DeviceModel = 3    // Kid Scale
+	DeviceModelSmartBodyAnalyzer    DeviceModel = 4    // Smart Body Analyzer
+	DeviceModelBodyPlus             DeviceModel = 5    // Body+
+	DeviceModelBodyCardio           DeviceModel = 6    // Body Cardio
+	DeviceModelBody                 DeviceModel = 7    // Body
+	DeviceModelWBS10                DeviceModel = 11   // WBS10
+	DeviceModelWBS11                DeviceModel = 12   // WBS11
+	DeviceModelSmartBabyMonitor     DeviceModel = 21   // Smart Baby Monitor
+	DeviceModelHome                 DeviceModel = 22   // Withings Home
+	DeviceModelBPMV1                DeviceModel = 41   // Blood Pressure Monitor V1
+	DeviceModelBPMV2                DeviceModel = 42   // Blood Pressure Monitor V2
+	DeviceModelBPMV3                DeviceModel = 43   // Blood Pressure Monitor V3
+	DeviceModelBPMCore              DeviceModel = 44   // BPM Core
+	DeviceModelBPMConnect           DeviceModel = 45   // BPM Connect
+	DeviceModelPulse                DeviceModel = 51   // Pulse
+	DeviceModelActivite             DeviceModel = 52   // Activite
+	DeviceModelActivitePopSteel     DeviceModel = 53   // Activite (Pop, Steel)
+	DeviceModelGo                   DeviceModel = 54   // Withings Go
+	DeviceModelActiviteSteelHR      DeviceModel = 55   // Activite Steel HR
+	DeviceModelPulseHR              DeviceModel = 58   // Pulse HR
+	DeviceModelActiviteSteelHRSport DeviceModel = 59   // Activite Steel HR Sport Edition
+	DeviceModelAuraDock             DeviceModel = 60   // Aura Dock
+	DeviceModelAuraSensor           DeviceModel = 61   // Aura Sensor
+	DeviceModelAuraSensorV2         DeviceModel = 62   // Aura Sensor V2
+	DeviceModelSleepAnalyzer        DeviceModel = 63   // Sleep Analyzer
+	DeviceModelThermo               DeviceModel = 70   // Thermo
+	DeviceModelMoveECG              DeviceModel = 91   // Move ECG
+	DeviceModelMoveECG2             DeviceModel = 92   // Move ECG
+	DeviceModelScanWatch            DeviceModel = 93   // ScanWatch
+	DeviceModelIOSStepTracker       DeviceModel = 1051 // iOS Step Tracker
+	DeviceModelIOSStepTracker2      DeviceModel = 1052 // iOS Step Tracker
+	DeviceModelAndroidStepTracker   DeviceModel = 1053 // Android Step Tracker
+	DeviceModelAndroidStepTracker2  DeviceModel = 1054 // Android Step Tracker
+	DeviceModelGPSTracker           DeviceModel = 1055 // GPS Tracker
+)
+
+var deviceModelNames = map[DeviceModel]string{
+	DeviceModelWBS01:                "Withings WBS01",
+	DeviceModelWS30:                 "WS30",
+	DeviceModelKidScale:             "Kid Scale",
+	DeviceModelSmartBodyAnalyzer:    "Smart Body Analyzer",
+	DeviceModelBodyPlus:             "Body+",
+	DeviceModelBodyCardio:           "Body Cardio",
+	DeviceModelBody:                 "Body",
+	DeviceModelWBS10:                "WBS10",
+	DeviceModelWBS11:                "WBS11",
+	DeviceModelSmartBabyMonitor:     "Smart Baby Monitor",
+	DeviceModelHome:                 "Withings Home",
+	DeviceModelBPMV1:                "Blood Pressure Monitor V1",
+	DeviceModelBPMV2:                "Blood Pressure Monitor V2",
+	DeviceModelBPMV3:                "Blood Pressure Monitor V3",
+	DeviceModelBPMCore:              "BPM Core",
+	DeviceModelBPMConnect:           "BPM Connect",
+	DeviceModelPulse:                "Pulse",
+	DeviceModelActivite:             "Activite",
+	DeviceModelActivitePopSteel:     "Activite (Pop, Steel)",
+	DeviceModelGo:                   "Withings Go",
+	DeviceModelActiviteSteelHR:      "Activite Steel HR",
+	DeviceModelPulseHR:              "Pulse HR",
+	DeviceModelActiviteSteelHRSport: "Activite Steel HR Sport Edition",
+	DeviceModelAuraDock:             "Aura Dock",
+	DeviceModelAuraSensor:           "Aura Sensor",
+	DeviceModelAuraSensorV2:         "Aura Sensor V2",
+	DeviceModelSleepAnalyzer:        "Sleep Analyzer",
+	DeviceModelThermo:               "Thermo",
+	DeviceModelMoveECG:              "Move ECG",
+	DeviceModelMoveECG2:             "Move ECG",
+	DeviceModelScanWatch:            "ScanWatch",
+	DeviceModelIOSStepTracker:       "iOS Step Tracker",
+	DeviceModelIOSStepTracker2:      "iOS Step Tracker",
+	DeviceModelAndroidStepTracker:   "Android Step Tracker",
+	DeviceModelAndroidStepTracker2:  "Android Step Tracker",
+	DeviceModelGPSTracker:           "GPS Tracker",
+}
+
+// IsValid checks if v is a known DeviceModel.
+func (v DeviceModel) IsValid() bool {
+	_, ok := deviceModelNames[v]
+
+	return ok
+}
+
+// String returns the human readable name of v.
+func (v DeviceModel) String() string {
+	if name, ok := deviceModelNames[v]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("DeviceModel(%d)", int(v))
+}
+
+// BatteryLevel is the battery status of a device.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/userv2-getdevice
+type BatteryLevel string
+
+// BatteryLevel values
+const (
+	BatteryLevelHigh   BatteryLevel = "high"   // High (above 75%)
+	BatteryLevelMedium BatteryLevel = "medium" // Medium (between 30% and 75%)
+	BatteryLevelLow    BatteryLevel = "low"    // Low (below 30%)
+)
+
+var validBatteryLevelValues = map[BatteryLevel]struct{}{
+	BatteryLevelHigh:   {},
+	BatteryLevelMedium: {},
+	BatteryLevelLow:    {},
+}
+
+// IsValid checks if v is a valid BatteryLevel.
+func (v BatteryLevel) IsValid() bool {
+	_, ok := validBatteryLevelValues[v]
+
+	return ok
+}
+
+// AllBatteryLevels is the list of all supported battery levels.
+func AllBatteryLevels() []BatteryLevel {
+	return []BatteryLevel{
+		BatteryLevelHigh,
+		BatteryLevelMedium,
+		BatteryLevelLow,
+	}
+}
+
+var batteryLevelNames = map[BatteryLevel]string{
+	BatteryLevelHigh:   "High",
+	BatteryLevelMedium: "Medium",
+	BatteryLevelLow:    "Low",
+}
+
+// String returns the human readable name of v.
+func (v BatteryLevel) String() string {
+	if name, ok := batteryLevelNames[v]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("BatteryLevel(%s)", string(v))
+}
+
+// GetDevice returns the list of user linked devices.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/userv2-getdevice
+func (s *UserService) GetDevice(ctx context.Context) ([]Device, *Response, error) {
+	if s == nil || s.client == nil {
+		return nil, nil, errClientNotInitialized
+	}
+
+	if err := s.client.requireScope(ctx, scopeUserInfo); err != nil {
+		return nil, nil, err
+	}
+
+	const urlPath = "v2/user"
+
+	form := url.Values{
+		"action": {"getdevice"},
+	}
+
+	deviceResp := new(getdeviceResponse)
+
+	resp, err := s.client.PostForm(ctx, urlPath, form, deviceResp)
+
+	return deviceResp.Body.Devices, resp, err
+}
+
+type getgoalsResponse struct {
+	Body struct {
+		Goals Goals `json:"goals"`
+	} `json:"body"`
+}
+
+// Goals are the objectives of the user.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/userv2-getgoals
+type Goals struct {
+	Steps  int        `json:"steps"`
+	Sleep  int        `json:"sleep"` // In seconds
+	Weight WeightGoal `json:"weight"`
+}
+
+// WeightGoal is the weight objective of the user.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/userv2-getgoals
+type WeightGoal struct {
+	Value int `json:"value"`
+	Unit  int `json:"unit"`
+}
+
+// FloatValue returns the real value of the weight goal (in kg).
+func (g WeightGoal) FloatValue() float64 {
+	return float64(g.Value) * math.Pow10(g.Unit)
+}
+
+// GetGoals returns the goals of the user.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/userv2-getgoals
+func (s *UserService) GetGoals(ctx context.Context) (*Goals, *Response, error) {
+	if s == nil || s.client == nil {
+		return nil, nil, errClientNotInitialized
+	}
+
+	if err := s.client.requireScope(ctx, scopeUserInfo); err != nil {
+		return nil, nil, err
+	}
+
+	const urlPath = "v2/user"
+
+	form := url.Values{
+		"action": {"getgoals"},
+	}
+
+	goalsResp := new(getgoalsResponse)
+
+	resp, err := s.client.PostForm(ctx, urlPath, form, goalsResp)
+
+	return &goalsResp.Body.Goals, resp, err
+}
+
+// Unlink revokes the access of the application to the user's data.
+//
+// The Client must be authorized as the user and configured with a Signer.
+//
+// Withings API docs: https://developer.withings.com/api-reference/#operation/userv2-unlink
+func (s *UserService) Unlink(ctx context.Context) (*Response, error) {
+	if s == nil || s.client == nil {
+		return nil, errClientNotInitialized
+	}
+
+	nonce, resp, err := s.client.Nonce(ctx)
+	if err != nil {
+		return resp, err
+	}
+
+	const urlPath = "v2/user"
+
+	form := url.Values{
+		"action": {"unlink"},
+		"nonce":  {nonce},
+	}
+
+	s.client.signer.Sign(form)
+
+	return s.client.PostForm(ctx, urlPath, form, nil)
+}
@@ -0,0 +1,162 @@
+package withings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUserService_GetDevice(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v2/user", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("action"), "getdevice"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{"devices":[{"type":"Scale","model":"Body Cardio","model_id":6,"battery":"high","deviceid":"abc","timezone":"Europe/Paris","last_session_date":1594159644}]}}`)
+	})
+
+	devices, _, err := client.User.GetDevice(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Device{
+		{
+			Type:            "Scale",
+			Model:           "Body Cardio",
+			ModelID:         6,
+			Battery:         BatteryLevelHigh,
+			DeviceID:        "abc",
+			Timezone:        "Europe/Paris",
+			LastSessionDate: 1594159644,
+		},
+	}
+
+	if !reflect.DeepEqual(devices, want) {
+		t.Errorf("devices = %+v; want %+v", devices, want)
+	}
+}
+
+func TestBatteryLevel(t *testing.T) {
+	if !BatteryLevelLow.IsValid() {
+		t.Error("BatteryLevelLow is supposed to be valid")
+	}
+
+	if BatteryLevel("empty").IsValid() {
+		t.Error("non existent BatteryLevel should not be valid")
+	}
+
+	for _, level := range AllBatteryLevels() {
+		if !level.IsValid() {
+			t.Errorf("%s is supposed to be valid", level)
+		}
+	}
+
+	if got, want := BatteryLevelMedium.String(), "Medium"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+
+	if got, want := BatteryLevel("empty").String(), "BatteryLevel(empty)"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+
+	if !(Device{Battery: BatteryLevelLow}).LowBattery() {
+		t.Error("device should have low battery")
+	}
+
+	if (Device{Battery: BatteryLevelHigh}).LowBattery() {
+		t.Error("device should not have low battery")
+	}
+}
+
+func TestUserService_GetGoals(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/v2/user", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("action"), "getgoals"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{"goals":{"steps":10000,"sleep":28800,"weight":{"value":70500,"unit":-3}}}}`)
+	})
+
+	goals, _, err := client.User.GetGoals(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if goals.Steps != 10000 || goals.Sleep != 28800 || goals.Weight.FloatValue() != 70.5 {
+		t.Errorf("unexpected goals: %+v", goals)
+	}
+}
+
+func TestDeviceModel(t *testing.T) {
+	if got, want := DeviceModelBodyCardio.String(), "Body Cardio"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+
+	if got, want := DeviceModel(9999).String(), "DeviceModel(9999)"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+
+	if DeviceModel(9999).IsValid() {
+		t.Error("unknown DeviceModel should not be valid")
+	}
+}
+
+func TestUserService_Unlink(t *testing.T) {
+	signer := NewSigner("client", "secret")
+
+	client, mux := setup(t, WithSigner(signer))
+
+	mux.HandleFunc("/v2/signature", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{"nonce":"abc"}}`)
+	})
+
+	var called bool
+
+	mux.HandleFunc("/v2/user", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+
+		if got, want := r.FormValue("action"), "unlink"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		if got, want := r.FormValue("client_id"), "client"; got != want {
+			t.Errorf("client_id = %q; want %q", got, want)
+		}
+
+		if got, want := r.FormValue("nonce"), "abc"; got != want {
+			t.Errorf("nonce = %q; want %q", got, want)
+		}
+
+		if got, want := r.FormValue("signature"), signer.Signature(r.PostForm); got != want {
+			t.Errorf("signature = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	_, err := client.User.Unlink(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !called {
+		t.Error("unlink should be called")
+	}
+
+	t.Run("NoSigner", func(t *testing.T) {
+		client, _ := setup(t)
+
+		_, err := client.User.Unlink(context.Background())
+		if !errors.Is(err, errSignerNotConfigured) {
+			t.Errorf("error = %v; want %v", err, errSignerNotConfigured)
+		}
+	})
+}
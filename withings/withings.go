@@ -1,14 +1,19 @@
 package withings
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 )
@@ -21,16 +26,56 @@ const (
 )
 
 // A Client manages communication with the Withings API.
+//
+// A Client is safe for concurrent use by multiple goroutines:
+// its settings are configured using ClientOptions when calling NewClient and cannot be modified afterwards,
+// so a single Client can be shared (eg. to sync the data of multiple users in parallel).
+// Per-request settings (eg. the base URL or the request ID) can be set in the context instead.
 type Client struct {
 	client *http.Client // HTTP client used to communicate with the API.
 
 	// Base URL for API requests. Defaults to the public Withings API, but can be
 	// set to a different URL to use the Withings HIPAA endpoint.
-	// BaseURL should always be specified with a trailing slash.
-	BaseURL *url.URL
+	// baseURL always has a trailing slash.
+	baseURL *url.URL
 
 	// User agent used when communicating with the Withings API.
-	UserAgent string
+	userAgent string
+
+	// maxRetries is the maximum number of times a failed request is retried.
+	// Retries are disabled when maxRetries is zero.
+	maxRetries int
+
+	// retryWaitMin and retryWaitMax bound the exponential backoff between retries.
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+
+	// checkRetry decides whether a failed request should be retried.
+	// If nil, RetryableError is used.
+	checkRetry func(err error, resp *Response) bool
+
+	// requestTimeout limits the duration of a single request
+	// (including reading the response body). Zero means no timeout.
+	requestTimeout time.Duration
+
+	// signer is used to sign requests sent to signature protected actions
+	// (eg. requesting a nonce). It's only required for those actions.
+	signer *Signer
+
+	// tokenRevoker is used by Disconnect to revoke the OAuth2 token of the user.
+	tokenRevoker TokenRevoker
+
+	// Functions called with every request before it is sent.
+	requestMutators []func(*http.Request) error
+
+	// Scopes granted by the user (if known).
+	scopes []string
+
+	// demo is true if the Client accesses the data of the demo user.
+	demo bool
+
+	// logger is called with every request after it is sent (if not nil).
+	logger func(entry RequestLogEntry)
 
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
@@ -39,33 +84,197 @@ type Client struct {
 	Heart   *HeartService
 	Sleep   *SleepService
 	Notify  *NotifyService
+	User    *UserService
 }
 
 type service struct {
 	client *Client
 }
 
+// errClientNotInitialized is returned by service methods
+// when the service was not created by NewClient.
+var errClientNotInitialized = errors.New("client not initialized; use withings.NewClient")
+
+// ClientOption configures a Client.
+type ClientOption func(c *Client)
+
+// WithHTTPClient sets the HTTP client used to communicate with the API.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.client = httpClient
+	}
+}
+
+// WithBaseURL sets the base URL for API requests.
+// A trailing slash is added to the URL path if it's missing.
+func WithBaseURL(baseURL *url.URL) ClientOption {
+	return func(c *Client) {
+		u := *baseURL
+
+		if !strings.HasSuffix(u.Path, "/") {
+			u.Path += "/"
+		}
+
+		c.baseURL = &u
+	}
+}
+
+// WithUserAgent sets the user agent used when communicating with the API.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithMaxRetries sets the maximum number of times a failed request is retried
+// (see WithCheckRetry and RetryableError). Retries are disabled when n is zero.
+func WithMaxRetries(n int) ClientOption {
+	return func(c *Client) {
+		c.maxRetries = n
+	}
+}
+
+// WithRetryWait sets the bounds of the exponential backoff between retries.
+//
+// The Retry-After header of a response takes precedence over the backoff.
+func WithRetryWait(min time.Duration, max time.Duration) ClientOption {
+	return func(c *Client) {
+		c.retryWaitMin = min
+		c.retryWaitMax = max
+	}
+}
+
+// WithCheckRetry sets the function deciding whether a failed request should be retried.
+// RetryableError is used by default.
+func WithCheckRetry(fn func(err error, resp *Response) bool) ClientOption {
+	return func(c *Client) {
+		c.checkRetry = fn
+	}
+}
+
+// WithRequestTimeout limits the duration of a single request
+// (including reading the response body). Zero means no timeout.
+//
+// The timeout applies to each attempt separately when retrying.
+// If the context of the request has an earlier deadline, that deadline is respected.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.requestTimeout = timeout
+	}
+}
+
+// WithRequestMutator registers a function that is called with every request
+// right before it is sent.
+//
+// It can be used to add custom headers (eg. tracing headers) or otherwise modify the request.
+// If the function returns an error, the request is aborted and the error is returned.
+// Mutators are called in the order they were registered.
+//
+// Mutators receive a copy of the request (the caller's request is never modified)
+// and are called once for every attempt, so retries don't accumulate modifications.
+func WithRequestMutator(fn func(req *http.Request) error) ClientOption {
+	return func(c *Client) {
+		c.requestMutators = append(c.requestMutators, fn)
+	}
+}
+
+// WithLogger sets a function that is called with every request after it is sent.
+func WithLogger(logger func(entry RequestLogEntry)) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithDemo marks the Client as accessing the data of the demo user
+// (ie. the token was obtained using demo mode).
+//
+// Withings does not tell demo data apart in responses,
+// so marking the Client lets downstream code guard against persisting demo data.
+// See Client.IsDemo and Response.Demo.
+func WithDemo() ClientOption {
+	return func(c *Client) {
+		c.demo = true
+	}
+}
+
+// BaseURL returns (a copy of) the base URL for API requests.
+func (c *Client) BaseURL() *url.URL {
+	u := *c.baseURL
+
+	return &u
+}
+
+// UserAgent returns the user agent used when communicating with the API.
+func (c *Client) UserAgent() string {
+	return c.userAgent
+}
+
+// MaxRetries returns the maximum number of times a failed request is retried.
+func (c *Client) MaxRetries() int {
+	return c.maxRetries
+}
+
+// RequestTimeout returns the timeout of a single request. Zero means no timeout.
+func (c *Client) RequestTimeout() time.Duration {
+	return c.requestTimeout
+}
+
+// IsDemo reports whether the Client was marked as accessing the data of the demo user (see WithDemo).
+func (c *Client) IsDemo() bool {
+	return c.demo
+}
+
 // NewClient returns a new Withings API client for the Public endpoint.
 // Provide an http.Client that will perform the authentication
 // (such as that provided by the golang.org/x/oauth2 library).
-func NewClient(httpClient *http.Client) *Client {
-	return newClient(httpClient, endpoint)
+func NewClient(httpClient *http.Client, opts ...ClientOption) *Client {
+	return newClient(httpClient, endpoint, opts)
 }
 
 // NewHIPAAClient returns a new Withings API client for the HIPAA endpoint.
 // Provide an http.Client that will perform the authentication
 // (such as that provided by the golang.org/x/oauth2 library).
-func NewHIPAAClient(httpClient *http.Client) *Client {
-	return newClient(httpClient, endpointHIPAA)
+func NewHIPAAClient(httpClient *http.Client, opts ...ClientOption) *Client {
+	return newClient(httpClient, endpointHIPAA, opts)
+}
+
+// Default settings used by NewClientWithDefaults.
+const (
+	defaultMaxRetries     = 3
+	defaultRetryWaitMin   = 1 * time.Second
+	defaultRetryWaitMax   = 30 * time.Second
+	defaultRequestTimeout = 30 * time.Second
+)
+
+// NewClientWithDefaults returns a new Withings API client for the Public endpoint
+// configured with sensible defaults for production use:
+// rate limited requests are retried with exponential backoff
+// and requests time out after a reasonable period.
+//
+// Provide an http.Client that will perform the authentication
+// (such as that provided by the golang.org/x/oauth2 library).
+func NewClientWithDefaults(httpClient *http.Client, opts ...ClientOption) *Client {
+	defaults := func(c *Client) {
+		c.maxRetries = defaultMaxRetries
+		c.retryWaitMin = defaultRetryWaitMin
+		c.retryWaitMax = defaultRetryWaitMax
+		c.requestTimeout = defaultRequestTimeout
+	}
+
+	return newClient(httpClient, endpoint, append([]ClientOption{defaults}, opts...))
 }
 
-func newClient(httpClient *http.Client, endpoint string) *Client {
+func newClient(httpClient *http.Client, endpoint string, opts []ClientOption) *Client {
 	baseURL, _ := url.Parse(endpoint)
 
 	c := &Client{
 		client:    httpClient,
-		BaseURL:   baseURL,
-		UserAgent: userAgent,
+		baseURL:   baseURL,
+		userAgent: userAgent,
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
 
 	c.common.client = c
@@ -74,6 +283,7 @@ func newClient(httpClient *http.Client, endpoint string) *Client {
 	c.Heart = (*HeartService)(&c.common)
 	c.Sleep = (*SleepService)(&c.common)
 	c.Notify = (*NotifyService)(&c.common)
+	c.User = (*UserService)(&c.common)
 
 	return c
 }
@@ -89,11 +299,85 @@ type Response struct {
 	// Withings API docs: https://developer.withings.com/api-reference#section/Response-status
 	Status int
 
+	// Error is the human-readable error message returned from the Withings API
+	// along with a non-zero Status (if any).
+	Error string
+
 	// These fields provide information whether there is more data to fetch.
 	// If more is true, sending a new request with the offset will return
 	// the next set of results.
 	More   bool
 	Offset int
+
+	// Items contains per-item statuses for batch operations
+	// that return a list of items instead of a single object.
+	//
+	// A zero top-level Status does not guarantee that every item succeeded:
+	// callers of batch operations should check the status of each item.
+	Items []ItemStatus
+
+	// Duration is the time it took to send the request and receive the response headers.
+	Duration time.Duration
+
+	// RateLimited is true if the request was rejected
+	// because of too many requests (status 601 or HTTP 429).
+	RateLimited bool
+
+	// RetryAfter is the time to wait before sending the next request
+	// as indicated by the Retry-After (or X-Next-Request-After) header.
+	// Zero if the header is missing.
+	RetryAfter time.Duration
+
+	// RequestID is the request ID returned in the RequestIDHeader header (if any).
+	RequestID string
+
+	// Demo is true if the response was received by a Client marked as demo (see WithDemo).
+	Demo bool
+}
+
+// NextOptions returns the options to fetch the next page with
+// and whether there is a next page at all.
+//
+//	for {
+//		measures, resp, err := client.Measure.Getmeas(ctx, types, category, opts)
+//		// ...
+//
+//		var ok bool
+//		if opts, ok = resp.NextOptions(opts); !ok {
+//			break
+//		}
+//	}
+func (r *Response) NextOptions(opts MeasureGetOptions) (MeasureGetOptions, bool) {
+	if r == nil || !r.More {
+		return opts, false
+	}
+
+	opts.Offset = r.Offset
+
+	return opts, true
+}
+
+// NextActivityOptions works like NextOptions, but for ActivityGetOptions.
+func (r *Response) NextActivityOptions(opts ActivityGetOptions) (ActivityGetOptions, bool) {
+	if r == nil || !r.More {
+		return opts, false
+	}
+
+	opts.Offset = r.Offset
+
+	return opts, true
+}
+
+// ItemStatus is the status of a single item in a batch response.
+type ItemStatus struct {
+	// Index of the item in the response body.
+	Index int
+
+	// Status code returned for the item.
+	Status int
+
+	// Error message returned for the item (if any).
+	Error string
 }
 
 // newResponse creates a new Response for the provided http.Response.
@@ -101,6 +385,9 @@ type Response struct {
 func newResponse(r *http.Response) *Response {
 	response := &Response{HttpResponse: r}
 
+	response.RetryAfter, _ = retryAfter(response)
+	response.RequestID = r.Header.Get(RequestIDHeader)
+
 	return response
 }
 
@@ -108,31 +395,113 @@ func newResponse(r *http.Response) *Response {
 // with data's keys and values URL-encoded as the request body.
 //
 // The Content-Type header is set to application/x-www-form-urlencoded.
-// To set other headers, use NewRequest and Do.
+// To set other headers, use NewFormRequest and Do.
 //
 // A relative URL can be provided in url,
 // in which case it is resolved relative to the BaseURL of the Client.
 // Relative URLs should always be specified without a preceding slash.
 func (c *Client) PostForm(ctx context.Context, url string, data url.Values, v interface{}) (resp *Response, err error) {
-	req, err := c.NewRequest(ctx, http.MethodPost, url, strings.NewReader(data.Encode()))
+	req, err := c.NewFormRequest(ctx, url, data)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
 	return c.Do(req, v)
 }
 
+type baseURLContextKey struct{}
+
+// ContextWithBaseURL returns a copy of ctx that routes requests made with it
+// to baseURL instead of the BaseURL of the Client.
+//
+// This is useful for sending individual requests to the HIPAA endpoint
+// (or vice versa) without maintaining a separate Client.
+// baseURL should always be specified with a trailing slash.
+func ContextWithBaseURL(ctx context.Context, baseURL *url.URL) context.Context {
+	return context.WithValue(ctx, baseURLContextKey{}, baseURL)
+}
+
+// ContextWithPublicEndpoint returns a copy of ctx that routes requests made with it
+// to the Public endpoint.
+func ContextWithPublicEndpoint(ctx context.Context) context.Context {
+	baseURL, _ := url.Parse(endpoint)
+
+	return ContextWithBaseURL(ctx, baseURL)
+}
+
+// ContextWithHIPAAEndpoint returns a copy of ctx that routes requests made with it
+// to the HIPAA endpoint.
+func ContextWithHIPAAEndpoint(ctx context.Context) context.Context {
+	baseURL, _ := url.Parse(endpointHIPAA)
+
+	return ContextWithBaseURL(ctx, baseURL)
+}
+
+type httpClientContextKey struct{}
+
+// ContextWithHTTPClient returns a copy of ctx that sends requests made with it
+// using httpClient instead of the HTTP client of the Client.
+//
+// This is useful when requests of different users need different transports
+// (eg. authentication or egress proxies) or for injecting a client in tests.
+func ContextWithHTTPClient(ctx context.Context, httpClient *http.Client) context.Context {
+	return context.WithValue(ctx, httpClientContextKey{}, httpClient)
+}
+
+// httpClient returns the HTTP client requests made with ctx should be sent with.
+func (c *Client) httpClient(ctx context.Context) *http.Client {
+	if httpClient, ok := ctx.Value(httpClientContextKey{}).(*http.Client); ok && httpClient != nil {
+		return httpClient
+	}
+
+	return c.client
+}
+
+// RequestIDHeader is the header carrying the request (correlation) ID of a request.
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID returns a copy of ctx that attaches requestID
+// to requests made with it in the RequestIDHeader header.
+//
+// This is useful for correlating Withings API calls with traces of the calling application.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID set in ctx by ContextWithRequestID (if any).
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDContextKey{}).(string)
+
+	return requestID, ok && requestID != ""
+}
+
+// resolveBaseURL returns the base URL requests made with ctx should be sent to.
+func (c *Client) resolveBaseURL(ctx context.Context) *url.URL {
+	if baseURL, ok := ctx.Value(baseURLContextKey{}).(*url.URL); ok && baseURL != nil {
+		return baseURL
+	}
+
+	return c.baseURL
+}
+
 // NewRequest creates an API request. A relative URL can be provided in urlStr,
-// in which case it is resolved relative to the BaseURL of the Client.
+// in which case it is resolved relative to the BaseURL of the Client
+// (or the base URL set in ctx by ContextWithBaseURL).
 // Relative URLs should always be specified without a preceding slash.
 func (c *Client) NewRequest(ctx context.Context, method string, urlStr string, body io.Reader) (*http.Request, error) {
-	if !strings.HasSuffix(c.BaseURL.Path, "/") {
-		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
+	if ctx == nil {
+		return nil, errNonNilContext
+	}
+
+	baseURL := c.resolveBaseURL(ctx)
+
+	if !strings.HasSuffix(baseURL.Path, "/") {
+		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", baseURL)
 	}
 
-	u, err := c.BaseURL.Parse(urlStr)
+	u, err := baseURL.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -142,24 +511,136 @@ func (c *Client) NewRequest(ctx context.Context, method string, urlStr string, b
 		return nil, err
 	}
 
-	if c.UserAgent != "" {
-		req.Header.Set("User-Agent", c.UserAgent)
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	if requestID, ok := RequestIDFromContext(ctx); ok {
+		req.Header.Set(RequestIDHeader, requestID)
+	}
+
+	return req, nil
+}
+
+// NewRequestWithJSON creates an API request with v JSON encoded as the request body.
+// The Content-Type header is set to application/json.
+//
+// URL resolution follows the same rules as in NewRequest.
+func (c *Client) NewRequestWithJSON(ctx context.Context, method string, urlStr string, v interface{}) (*http.Request, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewRequest(ctx, method, urlStr, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
+
+// NewFormRequest creates a POST API request with data's keys and values URL-encoded as the request body.
+//
+// The Content-Type header is set to application/x-www-form-urlencoded.
+// Every Withings API call requires an action parameter: ErrMissingAction is returned if data does not contain one.
+func (c *Client) NewFormRequest(ctx context.Context, urlStr string, data url.Values) (*http.Request, error) {
+	if data.Get("action") == "" {
+		return nil, ErrMissingAction
 	}
 
+	req, err := c.NewRequest(ctx, http.MethodPost, urlStr, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", formContentType)
+
 	return req, nil
 }
 
+const formContentType = "application/x-www-form-urlencoded"
+
+// ErrMissingAction is returned when a form request body does not contain an action parameter.
+var ErrMissingAction = errors.New("form body must contain an action")
+
+// checkFormRequest makes sure a POST request declared as a form (see NewFormRequest)
+// carries the action parameter required by the Withings API.
+//
+// Requests without a form Content-Type and requests whose body cannot be read
+// without consuming it (ie. GetBody is nil) are left unchecked. The request is never modified.
+func checkFormRequest(req *http.Request) error {
+	if req.Method != http.MethodPost || req.GetBody == nil || req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || mediaType != formContentType {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	form, err := url.ParseQuery(string(b))
+	if err != nil {
+		return fmt.Errorf("invalid form body: %w", err)
+	}
+
+	if form.Get("action") == "" {
+		return ErrMissingAction
+	}
+
+	return nil
+}
+
 var errNonNilContext = errors.New("context must be non-nil")
 
 // BareDo sends an API request and lets you handle the api response. If an error
 // or API Error occurs, the error will contain more information. Otherwise you
 // are supposed to read and close the response's Body.
+//
+// POST requests with a form Content-Type are checked for an action parameter before sending
+// (ErrMissingAction is returned otherwise).
 func (c *Client) BareDo(req *http.Request) (*Response, error) {
 	if req.Context() == nil {
 		return nil, errNonNilContext
 	}
 
-	resp, err := c.client.Do(req)
+	if len(c.requestMutators) > 0 {
+		// Mutate a copy, so the caller's request (which may be replayed on retry) stays untouched.
+		req = req.Clone(req.Context())
+
+		for _, mutate := range c.requestMutators {
+			if err := mutate(req); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := checkFormRequest(req); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	resp, err := c.httpClient(req.Context()).Do(req)
+	duration := time.Since(start)
+
+	if c.logger != nil {
+		c.logger(newRequestLogEntry(req, resp, duration, err))
+	}
+
 	if err != nil {
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
@@ -173,61 +654,239 @@ func (c *Client) BareDo(req *http.Request) (*Response, error) {
 	}
 
 	response := newResponse(resp)
+	response.Demo = c.demo
+	response.Duration = duration
 
 	return response, err
 }
 
 type apiResponse struct {
-	Status int `json:"status"`
+	Status int    `json:"status"`
+	Error  string `json:"error"`
+
+	// Body is usually an object, but batch operations may return a list of items.
+	Body interface{} `json:"body"`
+}
+
+type apiPagination struct {
+	More   bool `json:"more"`
+	Offset int  `json:"offset"`
+}
+
+type apiItem struct {
+	Status int    `json:"status"`
+	Error  string `json:"error"`
+}
 
-	Body struct {
-		More   bool `json:"more"`
-		Offset int  `json:"offset"`
-	} `json:"body"`
+// decodeBody extracts pagination and per-item status information from the response body.
+func (r *Response) decodeBody(body interface{}) error {
+	switch b := body.(type) {
+	case map[string]interface{}:
+		var pagination apiPagination
+
+		err := decode(b, &pagination)
+		if err != nil {
+			return err
+		}
+
+		r.More = pagination.More
+		r.Offset = pagination.Offset
+
+	case []interface{}:
+		r.Items = make([]ItemStatus, 0, len(b))
+
+		for i, rawItem := range b {
+			item, ok := rawItem.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if _, ok := item["status"]; !ok {
+				continue
+			}
+
+			var apiItem apiItem
+
+			err := decode(item, &apiItem)
+			if err != nil {
+				return err
+			}
+
+			r.Items = append(r.Items, ItemStatus{
+				Index:  i,
+				Status: apiItem.Status,
+				Error:  apiItem.Error,
+			})
+		}
+	}
+
+	return nil
 }
 
 // Do sends an API request and returns the API response. The API response is
 // JSON decoded and stored in the value pointed to by v, or returned as an
 // error if an API error has occurred.
+// If the API returns a non-zero status, an *ErrorResponse is returned.
 // If v is nil, and no error hapens, the response is returned as is.
+// If v is a *json.RawMessage, the undecoded body of the response is stored in it
+// (useful for accessing fields this package does not model yet).
+//
+// Failed requests are retried according to the retry settings of the Client
+// (see WithMaxRetries and WithCheckRetry).
+// By default, rate limited requests (status 601 or HTTP 429), upstream failures reported by the API
+// and requests that could not be sent (eg. connection failures) are retried (see RetryableError).
+// When retries are exhausted, the last error is returned.
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.do(req, v)
+		if attempt >= c.maxRetries || !c.shouldRetry(err, resp) {
+			return resp, err
+		}
+
+		retryReq, rerr := rewindRequest(req)
+		if rerr != nil {
+			return resp, err
+		}
+
+		wait := c.retryWait(attempt, resp)
+
+		// Don't bother waiting if the request would time out anyway.
+		if deadline, ok := req.Context().Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return resp, err
+		}
+
+		if werr := sleep(req.Context(), wait); werr != nil {
+			return resp, werr
+		}
+
+		req = retryReq
+	}
+}
+
+// do sends an API request once.
+func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
+	if c.requestTimeout > 0 {
+		// WithTimeout keeps the deadline of the parent context if it's earlier.
+		ctx, cancel := context.WithTimeout(req.Context(), c.requestTimeout)
+		defer cancel()
+
+		req = req.WithContext(ctx)
+	}
+
 	resp, err := c.BareDo(req)
 	if err != nil {
 		return resp, err
 	}
 	defer resp.HttpResponse.Body.Close()
 
-	body := map[string]interface{}{}
+	if resp.HttpResponse.StatusCode == http.StatusTooManyRequests {
+		resp.RateLimited = true
+
+		return resp, &RateLimitError{
+			Response:   resp,
+			StatusCode: resp.HttpResponse.StatusCode,
+		}
+	}
+
+	bodyReader, err := responseBodyReader(resp.HttpResponse)
+	if err != nil {
+		return resp, err
+	}
+	defer bodyReader.Close()
 
-	err = json.NewDecoder(resp.HttpResponse.Body).Decode(&body)
-	if err != nil && err != io.EOF { // nolint: errorlint // ignore EOF errors caused by empty response body
+	rawBody, err := ioutil.ReadAll(bodyReader)
+	if err != nil {
 		return resp, err
 	}
 
+	body := map[string]interface{}{}
+
+	// ignore empty response bodies
+	if len(bytes.TrimSpace(rawBody)) > 0 {
+		err = json.Unmarshal(rawBody, &body)
+		if err != nil {
+			return resp, &DecodeError{Response: resp, Body: rawBody, Err: err}
+		}
+	}
+
 	var apiResp apiResponse
 
 	err = decode(body, &apiResp)
 	if err != nil {
-		return resp, err
+		return resp, &DecodeError{Response: resp, Body: rawBody, Err: err}
 	}
 
-	err = decode(body, v)
+	resp.Status = apiResp.Status
+	resp.Error = apiResp.Error
+	resp.RateLimited = resp.Status == StatusTooManyRequests
+
+	err = resp.decodeBody(apiResp.Body)
 	if err != nil {
-		return resp, err
+		return resp, &DecodeError{Response: resp, Body: rawBody, Err: err}
 	}
 
-	resp.Status = apiResp.Status
-	resp.More = apiResp.Body.More
-	resp.Offset = apiResp.Body.Offset
+	if resp.Status != StatusOK {
+		return resp, &ErrorResponse{
+			Response: resp,
+			Status:   resp.Status,
+			Message:  resp.Error,
+		}
+	}
+
+	if raw, ok := v.(*json.RawMessage); ok {
+		var envelope struct {
+			Body json.RawMessage `json:"body"`
+		}
+
+		err = json.Unmarshal(rawBody, &envelope)
+		if err != nil {
+			return resp, &DecodeError{Response: resp, Body: rawBody, Err: err}
+		}
+
+		*raw = envelope.Body
+
+		return resp, nil
+	}
+
+	if v != nil {
+		err = decode(body, v)
+		if err != nil {
+			return resp, &DecodeError{Response: resp, Body: rawBody, Err: err}
+		}
+	}
 
 	return resp, err
 }
 
+// responseBodyReader returns a reader for the response body
+// that transparently decompresses gzip encoded bodies.
+//
+// Compression is normally negotiated and handled by the HTTP transport,
+// but gzip encoded bodies may still arrive (eg. when the transport has compression disabled).
+func responseBodyReader(r *http.Response) (io.ReadCloser, error) {
+	if !strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+		return ioutil.NopCloser(r.Body), nil
+	}
+
+	reader, err := gzip.NewReader(r.Body)
+	if err == io.EOF { // nolint: errorlint // empty response body
+		return ioutil.NopCloser(r.Body), nil
+	}
+
+	return reader, err
+}
+
 func decode(input interface{}, output interface{}) error {
+	return decodeWithMetadata(input, output, nil)
+}
+
+// decodeWithMetadata decodes input into output and records the decoded keys in metadata (if not nil).
+func decodeWithMetadata(input interface{}, output interface{}, metadata *mapstructure.Metadata) error {
 	config := &mapstructure.DecoderConfig{
-		Metadata: nil,
-		Result:   output,
-		TagName:  "json",
+		DecodeHook: decodeHook,
+		Metadata:   metadata,
+		Result:     output,
+		TagName:    "json",
 	}
 
 	decoder, err := mapstructure.NewDecoder(config)
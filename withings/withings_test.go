@@ -0,0 +1,513 @@
+package withings
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// setup sets up a test HTTP server along with a Client that is
+// configured to talk to that test server. Tests should register handlers on
+// mux which provide mock responses for the API method being tested.
+func setup(t *testing.T, opts ...ClientOption) (client *Client, mux *http.ServeMux) {
+	t.Helper()
+
+	mux = http.NewServeMux()
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	baseURL, _ := url.Parse(server.URL)
+
+	client = NewClient(nil, append([]ClientOption{WithHTTPClient(server.Client()), WithBaseURL(baseURL)}, opts...)...)
+
+	return client, mux
+}
+
+func TestClient_NewRequest_ContextBaseURL(t *testing.T) {
+	client := NewClient(http.DefaultClient)
+
+	t.Run("Default", func(t *testing.T) {
+		req, err := client.NewRequest(context.Background(), http.MethodPost, "measure", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := req.URL.String(), endpoint+"measure"; got != want {
+			t.Errorf("URL = %q; want %q", got, want)
+		}
+	})
+
+	t.Run("HIPAA", func(t *testing.T) {
+		req, err := client.NewRequest(ContextWithHIPAAEndpoint(context.Background()), http.MethodPost, "measure", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := req.URL.String(), endpointHIPAA+"measure"; got != want {
+			t.Errorf("URL = %q; want %q", got, want)
+		}
+	})
+
+	t.Run("MissingTrailingSlash", func(t *testing.T) {
+		baseURL, _ := url.Parse("https://example.com/api")
+
+		_, err := client.NewRequest(ContextWithBaseURL(context.Background(), baseURL), http.MethodPost, "measure", nil)
+		if err == nil {
+			t.Error("expected an error for a base URL without a trailing slash")
+		}
+	})
+}
+
+func TestContextWithRequestID(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get(RequestIDHeader), "request-id"; got != want {
+			t.Errorf("%s = %q; want %q", RequestIDHeader, got, want)
+		}
+
+		w.Header().Set(RequestIDHeader, "response-id")
+
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	ctx := ContextWithRequestID(context.Background(), "request-id")
+
+	resp, err := client.PostForm(ctx, "measure", url.Values{"action": {"getmeas"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := resp.RequestID, "response-id"; got != want {
+		t.Errorf("RequestID = %q; want %q", got, want)
+	}
+}
+
+func TestContextWithHTTPClient(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	var called bool
+
+	httpClient := &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			called = true
+
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
+
+	ctx := ContextWithHTTPClient(context.Background(), httpClient)
+
+	_, err := client.PostForm(ctx, "measure", url.Values{"action": {"getmeas"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !called {
+		t.Error("HTTP client in the context should be used")
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+func TestClient_NewRequestWithJSON(t *testing.T) {
+	client, _ := setup(t)
+
+	req, err := client.NewRequestWithJSON(context.Background(), http.MethodPost, "v2/measure", map[string]int{"value": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := req.Header.Get("Content-Type"), "application/json"; got != want {
+		t.Errorf("Content-Type = %q; want %q", got, want)
+	}
+
+	if got, want := req.URL.Path, "/v2/measure"; got != want {
+		t.Errorf("path = %q; want %q", got, want)
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := string(body), `{"value":1}`; got != want {
+		t.Errorf("body = %q; want %q", got, want)
+	}
+}
+
+func TestClient_NewFormRequest(t *testing.T) {
+	client, _ := setup(t)
+
+	req, err := client.NewFormRequest(context.Background(), "measure", url.Values{"action": {"getmeas"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := req.Method, http.MethodPost; got != want {
+		t.Errorf("method = %q; want %q", got, want)
+	}
+
+	if got, want := req.Header.Get("Content-Type"), "application/x-www-form-urlencoded"; got != want {
+		t.Errorf("Content-Type = %q; want %q", got, want)
+	}
+
+	t.Run("MissingAction", func(t *testing.T) {
+		_, err := client.NewFormRequest(context.Background(), "measure", url.Values{"userid": {"1"}})
+		if !errors.Is(err, ErrMissingAction) {
+			t.Errorf("error should be ErrMissingAction, got %v", err)
+		}
+	})
+}
+
+func TestClient_Do_FormRequest(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.FormValue("action"), "getmeas"; got != want {
+			t.Errorf("action = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	t.Run("ContentTypeParams", func(t *testing.T) {
+		req, err := client.NewRequest(context.Background(), http.MethodPost, "measure", strings.NewReader("action=getmeas"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+		_, err = client.Do(req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("MissingAction", func(t *testing.T) {
+		req, err := client.NewRequest(context.Background(), http.MethodPost, "measure", strings.NewReader("userid=1"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		_, err = client.Do(req, nil)
+		if !errors.Is(err, ErrMissingAction) {
+			t.Errorf("error should be ErrMissingAction, got %v", err)
+		}
+	})
+
+	t.Run("NonFormBody", func(t *testing.T) {
+		mux.HandleFunc("/raw", func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("Content-Type"); got != "" {
+				t.Errorf("Content-Type = %q; want none", got)
+			}
+
+			fmt.Fprint(w, `{"status":0,"body":{}}`)
+		})
+
+		req, err := client.NewRequest(context.Background(), http.MethodPost, "raw", strings.NewReader(`{"value":1}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = client.Do(req, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := req.Header.Get("Content-Type"); got != "" {
+			t.Errorf("Content-Type = %q; want none", got)
+		}
+	})
+}
+
+func TestWithRequestMutator(t *testing.T) {
+	client, mux := setup(t, WithRequestMutator(func(req *http.Request) error {
+		req.Header.Set("X-Trace-Id", "trace")
+
+		return nil
+	}))
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Trace-Id"), "trace"; got != want {
+			t.Errorf("X-Trace-Id = %q; want %q", got, want)
+		}
+
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	_, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"getmeas"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		mutatorErr := errors.New("abort")
+
+		client, mux := setup(t, WithRequestMutator(func(req *http.Request) error {
+			return mutatorErr
+		}))
+
+		mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+			t.Error("request should not be sent when a mutator fails")
+		})
+
+		_, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"getmeas"}}, nil)
+		if !errors.Is(err, mutatorErr) {
+			t.Errorf("error = %v; want %v", err, mutatorErr)
+		}
+	})
+}
+
+func TestClient_Do_Gzip(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+
+		gw := gzip.NewWriter(w)
+		defer gw.Close()
+
+		fmt.Fprint(gw, `{"status":0,"body":{"more":true,"offset":10}}`)
+	})
+
+	resp, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"getmeas"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !resp.More || resp.Offset != 10 {
+		t.Errorf("more = %t, offset = %d; want true, 10", resp.More, resp.Offset)
+	}
+}
+
+func TestClient_BareDo_Gzip(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Errorf("Accept-Encoding = %q; want gzip", r.Header.Get("Accept-Encoding"))
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+
+		gw := gzip.NewWriter(w)
+		defer gw.Close()
+
+		fmt.Fprint(gw, `{"status":0,"body":{}}`)
+	})
+
+	req, err := client.NewFormRequest(context.Background(), "measure", url.Values{"action": {"getmeas"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.BareDo(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.HttpResponse.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.HttpResponse.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := string(body), `{"status":0,"body":{}}`; got != want {
+		t.Errorf("body = %q; want %q", got, want)
+	}
+
+	if !resp.HttpResponse.Uncompressed {
+		t.Error("response should be decompressed by the transport")
+	}
+}
+
+func TestClient_Do_RawMessage(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{"new_field":[1,2,3]}}`)
+	})
+
+	var raw json.RawMessage
+
+	_, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"getmeas"}}, &raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := string(raw), `{"new_field":[1,2,3]}`; got != want {
+		t.Errorf("raw body = %s; want %s", got, want)
+	}
+}
+
+func TestClient_Do_BatchBody(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":[{"status":0},{"status":503,"error":"Invalid params"},"unknown"]}`)
+	})
+
+	resp, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"setmeas"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ItemStatus{
+		{Index: 0, Status: 0},
+		{Index: 1, Status: 503, Error: "Invalid params"},
+	}
+
+	if !reflect.DeepEqual(resp.Items, want) {
+		t.Errorf("items = %+v; want %+v", resp.Items, want)
+	}
+}
+
+func TestNewClient_Options(t *testing.T) {
+	baseURL, _ := url.Parse("https://example.com/api")
+
+	client := NewClient(http.DefaultClient, WithBaseURL(baseURL), WithUserAgent("myapp/1.0"))
+
+	if got, want := client.BaseURL().String(), "https://example.com/api/"; got != want {
+		t.Errorf("BaseURL = %q; want %q", got, want)
+	}
+
+	if got, want := baseURL.String(), "https://example.com/api"; got != want {
+		t.Errorf("WithBaseURL should not modify its argument: got %q; want %q", got, want)
+	}
+
+	if got, want := client.UserAgent(), "myapp/1.0"; got != want {
+		t.Errorf("UserAgent = %q; want %q", got, want)
+	}
+}
+
+func TestWithDemo(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{}}`)
+	})
+
+	if client.IsDemo() {
+		t.Error("client should not be marked as demo by default")
+	}
+
+	WithDemo()(client)
+
+	if !client.IsDemo() {
+		t.Error("client should be marked as demo")
+	}
+
+	resp, err := client.PostForm(context.Background(), "measure", url.Values{"action": {"getmeas"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !resp.Demo {
+		t.Error("response should be marked as demo")
+	}
+}
+
+// TestClient_Concurrent documents that a single Client can be used from multiple goroutines.
+// It's most useful when running tests with the race detector enabled.
+func TestClient_Concurrent(t *testing.T) {
+	client, mux := setup(t)
+
+	mux.HandleFunc("/measure", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":0,"body":{"measuregrps":[{"grpid":1}]}}`)
+	})
+
+	const goroutines = 10
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, goroutines)
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			ctx := ContextWithRequestID(context.Background(), fmt.Sprintf("request-%d", i))
+
+			_, _, err := client.Measure.Getmeas(ctx, AllMeasureTypes(), MeasureCategoryRealMeasure, MeasureGetOptions{})
+			errs <- err
+		}(i)
+	}
+
+	// Settings returned by the Client cannot be used to modify it while requests are in flight
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < goroutines; i++ {
+			baseURL := client.BaseURL()
+			baseURL.Path = "/modified/"
+		}
+	}()
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestResponse_NextOptions(t *testing.T) {
+	opts := MeasureGetOptions{Offset: 1, MaxPages: 2}
+
+	next, ok := (&Response{More: true, Offset: 5}).NextOptions(opts)
+	if !ok {
+		t.Fatal("there should be a next page")
+	}
+
+	if want := (MeasureGetOptions{Offset: 5, MaxPages: 2}); !reflect.DeepEqual(next, want) {
+		t.Errorf("NextOptions() = %+v; want %+v", next, want)
+	}
+
+	if _, ok := (&Response{Offset: 5}).NextOptions(opts); ok {
+		t.Error("there should be no next page")
+	}
+
+	if _, ok := (*Response)(nil).NextOptions(opts); ok {
+		t.Error("there should be no next page for a nil response")
+	}
+
+	t.Run("Activity", func(t *testing.T) {
+		next, ok := (&Response{More: true, Offset: 5}).NextActivityOptions(ActivityGetOptions{DeviceID: "a"})
+		if !ok {
+			t.Fatal("there should be a next page")
+		}
+
+		if want := (ActivityGetOptions{Offset: 5, DeviceID: "a"}); !reflect.DeepEqual(next, want) {
+			t.Errorf("NextActivityOptions() = %+v; want %+v", next, want)
+		}
+	})
+}
@@ -0,0 +1,124 @@
+// Package withingstest provides utilities for testing code that uses the Withings API client.
+package withingstest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/sagikazarmark/go-withings/withings"
+)
+
+// Request is a request recorded by a Server.
+type Request struct {
+	Method string
+	Path   string
+	Form   url.Values
+}
+
+// Action returns the action the request was sent for.
+func (r Request) Action() string {
+	return r.Form.Get("action")
+}
+
+// Server is a mock Withings API server for use in tests.
+//
+// Register canned responses with Handle and HandleError.
+// Requests to unregistered actions receive a "wrong action" error (status 2554).
+type Server struct {
+	*httptest.Server
+
+	// Client is a Withings API client configured to talk to the server.
+	Client *withings.Client
+
+	mu        sync.Mutex
+	responses map[string]string
+	requests  []Request
+}
+
+// NewServer starts and returns a new Server along with a Client configured to talk to it.
+// The caller should call Close when finished, to shut it down.
+//
+// Additional options are applied to the Client after the server specific ones.
+func NewServer(opts ...withings.ClientOption) *Server {
+	s := &Server{
+		responses: make(map[string]string),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	baseURL, _ := url.Parse(s.Server.URL)
+
+	opts = append([]withings.ClientOption{
+		withings.WithHTTPClient(s.Server.Client()),
+		withings.WithBaseURL(baseURL),
+	}, opts...)
+
+	s.Client = withings.NewClient(nil, opts...)
+
+	return s
+}
+
+// Handle registers a successful response for an action of an API path (eg. "measure" and "getmeas").
+//
+// body is the raw JSON content of the body field of the response envelope.
+func (s *Server) Handle(path string, action string, body string) {
+	s.handle(path, action, fmt.Sprintf(`{"status":0,"body":%s}`, body))
+}
+
+// HandleError registers an error response for an action of an API path.
+func (s *Server) HandleError(path string, action string, status int, message string) {
+	s.handle(path, action, fmt.Sprintf(`{"status":%d,"error":%q}`, status, message))
+}
+
+func (s *Server) handle(path string, action string, response string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[responseKey(path, action)] = response
+}
+
+// Requests returns the list of requests received by the server in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := make([]Request, len(s.requests))
+	copy(requests, s.requests)
+
+	return requests
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	req := Request{
+		Method: r.Method,
+		Path:   strings.TrimPrefix(r.URL.Path, "/"),
+		Form:   r.PostForm,
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	response, ok := s.responses[responseKey(req.Path, req.Action())]
+	s.mu.Unlock()
+
+	if !ok {
+		response = fmt.Sprintf(`{"status":%d,"error":"Wrong action or wrong webservice"}`, withings.StatusWrongAction)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	fmt.Fprint(w, response)
+}
+
+func responseKey(path string, action string) string {
+	return strings.Trim(path, "/") + "#" + action
+}
@@ -0,0 +1,63 @@
+package withingstest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sagikazarmark/go-withings/withings"
+)
+
+func TestServer(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.Handle("v2/user", "getgoals", `{"goals":{"steps":10000}}`)
+
+	goals, _, err := server.Client.User.GetGoals(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := goals.Steps, 10000; got != want {
+		t.Errorf("steps = %d; want %d", got, want)
+	}
+
+	requests := server.Requests()
+
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests; want 1", len(requests))
+	}
+
+	if got, want := requests[0].Path, "v2/user"; got != want {
+		t.Errorf("path = %q; want %q", got, want)
+	}
+
+	if got, want := requests[0].Action(), "getgoals"; got != want {
+		t.Errorf("action = %q; want %q", got, want)
+	}
+}
+
+func TestServer_HandleError(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	server.HandleError("v2/user", "getgoals", withings.StatusInvalidToken, "Invalid token")
+
+	_, _, err := server.Client.User.GetGoals(context.Background())
+	if !errors.Is(err, withings.ErrInvalidToken) {
+		t.Errorf("error = %v; want %v", err, withings.ErrInvalidToken)
+	}
+}
+
+func TestServer_Unregistered(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	_, _, err := server.Client.User.GetDevice(context.Background())
+
+	var errResp *withings.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Status != withings.StatusWrongAction {
+		t.Errorf("error = %v; want status %d", err, withings.StatusWrongAction)
+	}
+}